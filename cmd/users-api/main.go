@@ -2,10 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"net"
 	"os"
 	"strings"
 
+	dpb "github.com/DIMO-Network/devices-api/pkg/grpc"
 	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
 	_ "github.com/DIMO-Network/users-api/docs"
@@ -13,7 +16,9 @@ import (
 	"github.com/DIMO-Network/users-api/internal/config"
 	"github.com/DIMO-Network/users-api/internal/controllers"
 	"github.com/DIMO-Network/users-api/internal/database"
+	"github.com/DIMO-Network/users-api/internal/services"
 	pb "github.com/DIMO-Network/users-api/pkg/grpc"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
 	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
@@ -25,6 +30,7 @@ import (
 	"github.com/rs/zerolog"
 	_ "go.uber.org/automaxprocs"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 // @title DIMO User API
@@ -65,11 +71,69 @@ func main() {
 		if err := database.MigrateDatabase(ctx, logger, &settings.DB, command, "migrations"); err != nil {
 			logger.Fatal().Err(err).Msg("Failed to migrate datbase.")
 		}
+	case "email-conflicts":
+		// Preflight for the unique index on confirmed email addresses.
+		conflicts, err := database.FindEmailConflicts(ctx, dbs.DBS().Reader)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to look for conflicting email addresses.")
+		}
+		if err := database.WriteEmailConflicts(os.Stdout, conflicts); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to write report.")
+		}
+		if len(conflicts) != 0 {
+			logger.Fatal().Int("conflicts", len(conflicts)).Msg("Some confirmed email addresses are shared. Resolve these before migrating.")
+		}
+	case "merge-duplicates":
+		mergeDuplicates(ctx, logger, dbs, os.Args[2:])
 	default:
 		startWebAPI(logger, &settings, dbs)
 	}
 }
 
+// mergeDuplicates prints, and with -apply carries out, merge plans for accounts sharing a
+// confirmed Ethereum address.
+func mergeDuplicates(ctx context.Context, logger zerolog.Logger, dbs db.Store, args []string) {
+	fs := flag.NewFlagSet("merge-duplicates", flag.ExitOnError)
+	apply := fs.Bool("apply", false, "carry out the merges; without this, only print the plans")
+	address := fs.String("address", "", "only merge accounts with this address")
+	actor := fs.String("actor", "cli", "who is merging, for the audit trail")
+	_ = fs.Parse(args)
+
+	var addrs []common.Address
+	if *address != "" {
+		if !common.IsHexAddress(*address) {
+			logger.Fatal().Str("address", *address).Msg("Invalid Ethereum address.")
+		}
+		addrs = []common.Address{common.HexToAddress(*address)}
+	} else {
+		var err error
+		addrs, err = services.DuplicateAddresses(ctx, dbs.DBS().Reader)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to find duplicate accounts.")
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	for _, addr := range addrs {
+		plan, err := services.MergeDuplicates(ctx, dbs, addr, *actor, !*apply)
+		if err != nil {
+			if errors.Is(err, services.ErrNoDuplicates) {
+				continue
+			}
+			logger.Fatal().Err(err).Str("ethAddr", addr.Hex()).Msg("Failed to merge accounts.")
+		}
+		if err := enc.Encode(plan); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to write plan.")
+		}
+	}
+
+	if !*apply {
+		logger.Info().Int("addresses", len(addrs)).Msg("Dry run. Pass -apply to merge.")
+	}
+}
+
 func startWebAPI(logger zerolog.Logger, settings *config.Settings, dbs db.Store) {
 	app := fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
@@ -104,22 +168,82 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, dbs db.Store)
 		JWKSetURLs: []string{settings.JWTKeySetURL},
 	})
 
-	v1User := app.Group("/v1/user", auth)
+	ethClient, err := services.DialEthereum(context.Background(), settings.MainRPCURL)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to create Ethereum client.")
+	}
+
+	chain, err := services.NewChainClient(ethClient, settings)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to create chain client.")
+	}
+
+	indexer, err := services.NewWeb3UsageIndexer(dbs, ethClient, settings, &logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to create web3 usage indexer.")
+	}
 
-	userController := controllers.NewUserController(settings, dbs, &logger)
+	userController := controllers.NewUserController(settings, dbs, chain, indexer, &logger)
 
-	app.Post("/v1/check-email", userController.CheckEmail)
+	v1User := app.Group("/v1/user", auth, userController.ResolveUser)
 
-	app.Get("/v2/user", auth, userController.GetUserV2)
+	limits := services.NewInMemoryTokenBucketStore()
+
+	checkEmailLimit := controllers.CheckEmailRateLimit(settings, limits, &logger)
+	confirmationEmailLimit := controllers.ConfirmationEmailRateLimit(settings, limits, &logger)
+
+	app.Post("/v1/check-email", checkEmailLimit, userController.CheckEmail)
+
+	app.Get("/v2/user", auth, userController.ResolveUser, userController.GetUserV2)
 
 	v1User.Get("/", userController.GetUser)
+	v1User.Put("/", userController.UpdateUser)
 	v1User.Delete("/", userController.DeleteUser)
+	v1User.Post("/restore", userController.RestoreUser)
 	v1User.Post("/set-migrated", userController.SetMigrated)
+	v1User.Post("/send-confirmation-email", confirmationEmailLimit, userController.SendConfirmationEmail)
+	v1User.Post("/confirm-email", userController.ConfirmEmail)
+	v1User.Post("/web3/challenge/generate", userController.GenerateEthereumChallenge)
+	v1User.Post("/web3/challenge/submit", userController.SubmitEthereumChallenge)
+	v1User.Post("/submit-referral-code", userController.SubmitReferralCode)
+	v1User.Get("/referrals", userController.GetReferrals)
+	v1User.Post("/agree-tos", userController.AgreeTOS)
+	v1User.Get("/wallets", userController.ListWallets)
+	v1User.Post("/wallets", userController.AddWallet)
+	v1User.Delete("/wallets/:address", userController.RemoveWallet)
+	v1User.Post("/wallets/:address/primary", userController.SetPrimaryWallet)
+	v1User.Get("/export", userController.ExportUser)
+	v1User.Get("/export/:exportId", userController.GetExport)
 
 	logger.Info().Msg("Server started on port " + settings.Port)
 
 	go startGRPCServer(settings, dbs, &logger)
 
+	var brokers []string
+	for _, b := range strings.Split(settings.KafkaBrokers, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			brokers = append(brokers, b)
+		}
+	}
+
+	if len(brokers) == 0 {
+		// Events wait in the outbox until a relay with brokers picks them up.
+		logger.Warn().Msg("No Kafka brokers configured, not relaying user events.")
+	} else {
+		producer, err := services.NewKafkaEventProducer(brokers, settings.EventsTopic)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to create Kafka producer.")
+		}
+		defer producer.Close() //nolint
+
+		go services.NewOutboxRelay(dbs, producer, &logger).Run(context.Background())
+	}
+
+	go services.NewUserPurger(dbs, settings, &logger).Run(context.Background())
+	go services.NewExportWorker(dbs, &logger).Run(context.Background())
+
+	go indexer.Run(context.Background())
+
 	// Start Server
 	if err := app.Listen(":" + settings.Port); err != nil {
 		logger.Fatal().Err(err).Send()
@@ -133,8 +257,13 @@ func startGRPCServer(settings *config.Settings, dbs db.Store, logger *zerolog.Lo
 	}
 
 	logger.Info().Msgf("Starting gRPC server on port %s", settings.GRPCPort)
+	gc, err := grpc.NewClient(settings.DevicesAPIGRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to create devices-api client.")
+	}
+
 	server := grpc.NewServer()
-	pb.RegisterUserServiceServer(server, api.NewUserService(dbs, logger))
+	pb.RegisterUserServiceServer(server, api.NewUserService(dbs, dpb.NewUserDeviceServiceClient(gc), logger))
 
 	if err := server.Serve(lis); err != nil {
 		logger.Fatal().Err(err).Msg("gRPC server terminated unexpectedly")
@@ -1,4 +1,4 @@
-// Package docs Code generated by swaggo/swag. DO NOT EDIT
+// Package docs Code generated by swaggo/swag at 2026-10-17 20:32:27.186939819 +0000 UTC m=+0.299266331. DO NOT EDIT
 package docs
 
 import "github.com/swaggo/swag"
@@ -52,27 +52,33 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CheckEmailRequest"
+                            "$ref": "#/definitions/controllers.CheckEmailRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "0": {
-                        "description": "",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.CheckEmailResponse"
                         }
                     },
-                    "200": {
-                        "description": "OK",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CheckEmailResponse"
+                            "$ref": "#/definitions/controllers.ValidationErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limited. See the Retry-After header.",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
@@ -88,23 +94,28 @@ const docTemplate = `{
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get attributes for the authenticated user. If multiple records for the same user, gets the one with the email confirmed.",
+                "summary": "Get attributes for the authenticated user. Logins linked to another user get that user's attributes.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.UserResponse"
+                            "$ref": "#/definitions/controllers.UserResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
             },
             "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -119,7 +130,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.UserUpdateRequest"
+                            "$ref": "#/definitions/controllers.UserUpdateRequest"
                         }
                     }
                 ],
@@ -127,24 +138,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.UserResponse"
+                            "$ref": "#/definitions/controllers.UserResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ValidationErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
             },
             "delete": {
+                "description": "The user can be restored until the deletion grace period is over.",
                 "summary": "Delete the authenticated user. Fails if the user has any devices.",
                 "responses": {
                     "204": {
@@ -153,19 +165,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     },
                     "409": {
                         "description": "Returned if the user still has devices.",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
@@ -173,6 +185,11 @@ const docTemplate = `{
         },
         "/v1/user/agree-tos": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "summary": "Agree to the current terms of service",
                 "responses": {
                     "204": {
@@ -181,7 +198,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Returned if no terms of service version is configured.",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
@@ -189,6 +212,12 @@ const docTemplate = `{
         },
         "/v1/user/confirm-email": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "After five wrong keys, the key is discarded and a new email must be requested.",
                 "consumes": [
                     "application/json"
                 ],
@@ -200,7 +229,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ConfirmEmailRequest"
+                            "$ref": "#/definitions/controllers.ConfirmEmailRequest"
                         }
                     }
                 ],
@@ -211,13 +240,214 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a JSON document, or a ZIP archive containing it, with the user's record,\nterms of service history, referrals, wallets and migration status. With async=true\nthe export is built in the background: poll the URL in the Location header.",
+                "produces": [
+                    "application/json",
+                    "application/zip"
+                ],
+                "summary": "Export all data stored about the authenticated user.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "Either json or zip",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Build the export in the background",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ExportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/export/{exportId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the export once it's built, and its status until then.",
+                "produces": [
+                    "application/json",
+                    "application/zip"
+                ],
+                "summary": "Get an asynchronous export of the authenticated user's data.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export ID",
+                        "name": "exportId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ExportJobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Returned if the export has expired.",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Returned if the export failed.",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/referrals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List the users referred by the authenticated user.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "1-based page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ReferralsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Undo the deletion of the authenticated user, within the grace period.",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Returned if the user isn't deleted.",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Returned if the grace period is over.",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
@@ -225,6 +455,11 @@ const docTemplate = `{
         },
         "/v1/user/send-confirmation-email": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "summary": "Send a confirmation email to the authenticated user",
                 "responses": {
                     "204": {
@@ -233,19 +468,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Returned if too many emails have been sent recently.",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
@@ -261,7 +502,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
@@ -269,6 +516,14 @@ const docTemplate = `{
         },
         "/v1/user/submit-referral-code": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "summary": "Takes the referral code, validates and stores it",
                 "parameters": [
                     {
@@ -277,7 +532,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.SubmitReferralCodeRequest"
+                            "$ref": "#/definitions/controllers.SubmitReferralCodeRequest"
                         }
                     }
                 ],
@@ -285,19 +540,210 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.SubmitReferralCodeResponse"
+                            "$ref": "#/definitions/controllers.SubmitReferralCodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/wallets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List the authenticated user's wallets, primary first.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.WalletResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm the wallet by generating and submitting a web3 challenge for its address.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add an unconfirmed wallet to the authenticated user.",
+                "parameters": [
+                    {
+                        "description": "Wallet to add",
+                        "name": "addWalletRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.AddWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.WalletResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Returned if the user already has the wallet.",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/wallets/{address}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Remove a wallet from the authenticated user. The primary wallet can't be removed.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Returned if the wallet is the primary one.",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/v1/user/wallets/{address}/primary": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The primary wallet is the address reported by /v1/user and used for referrals.",
+                "summary": "Make a confirmed wallet the authenticated user's primary wallet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Returned if the wallet is unconfirmed.",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
@@ -305,24 +751,42 @@ const docTemplate = `{
         },
         "/v1/user/web3/challenge/generate": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "summary": "Generate a challenge message for the user to sign.",
+                "parameters": [
+                    {
+                        "description": "Address to confirm",
+                        "name": "challengeRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ChallengeRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ChallengeResponse"
+                            "$ref": "#/definitions/controllers.ChallengeResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
@@ -330,6 +794,15 @@ const docTemplate = `{
         },
         "/v1/user/web3/challenge/submit": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "If the user already has a confirmed address, this one is added as another wallet.",
+                "consumes": [
+                    "application/json"
+                ],
                 "summary": "Confirm ownership of an ethereum address by submitting a signature",
                 "parameters": [
                     {
@@ -338,7 +811,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ConfirmEthereumRequest"
+                            "$ref": "#/definitions/controllers.ConfirmEthereumRequest"
                         }
                     }
                 ],
@@ -349,13 +822,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
@@ -371,18 +844,18 @@ const docTemplate = `{
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get attributes for the authenticated user. If multiple records for the same user, gets the one with the email confirmed.",
+                "summary": "Get attributes for the authenticated user. Logins linked to another user get that user's attributes.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.UserResponse"
+                            "$ref": "#/definitions/controllers.UserResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.ErrorResponse"
+                            "$ref": "#/definitions/controllers.ErrorResponse"
                         }
                     }
                 }
@@ -390,7 +863,37 @@ const docTemplate = `{
         }
     },
     "definitions": {
-        "internal_controllers.ChallengeResponse": {
+        "controllers.AddWalletRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address is the wallet's Ethereum address. Confirm it with the web3 challenge endpoints.",
+                    "type": "string",
+                    "example": "0x142e0C7A098622Ea98E5D67034251C4dFA746B5d"
+                },
+                "kind": {
+                    "description": "Kind is either \"external\", the default, or \"in_app\".",
+                    "type": "string",
+                    "example": "external"
+                },
+                "label": {
+                    "description": "Label is an optional name for the wallet.",
+                    "type": "string",
+                    "example": "Hardware wallet"
+                }
+            }
+        },
+        "controllers.ChallengeRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address is the Ethereum address to be confirmed. If omitted, the user's current\nunconfirmed address is used.",
+                    "type": "string",
+                    "example": "0x71C7656EC7ab88b098defB751B7401B5f6d8976F"
+                }
+            }
+        },
+        "controllers.ChallengeResponse": {
             "type": "object",
             "properties": {
                 "challenge": {
@@ -403,7 +906,7 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_controllers.CheckEmailRequest": {
+        "controllers.CheckEmailRequest": {
             "type": "object",
             "properties": {
                 "address": {
@@ -413,7 +916,7 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_controllers.CheckEmailResponse": {
+        "controllers.CheckEmailResponse": {
             "type": "object",
             "properties": {
                 "inUse": {
@@ -421,19 +924,22 @@ const docTemplate = `{
                     "type": "boolean"
                 },
                 "wallets": {
-                    "type": "object",
-                    "properties": {
-                        "external": {
-                            "type": "integer"
-                        },
-                        "inApp": {
-                            "type": "integer"
-                        }
-                    }
+                    "$ref": "#/definitions/controllers.CheckWallets"
                 }
             }
         },
-        "internal_controllers.ConfirmEmailRequest": {
+        "controllers.CheckWallets": {
+            "type": "object",
+            "properties": {
+                "external": {
+                    "type": "integer"
+                },
+                "inApp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.ConfirmEmailRequest": {
             "type": "object",
             "properties": {
                 "key": {
@@ -443,7 +949,7 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_controllers.ConfirmEthereumRequest": {
+        "controllers.ConfirmEthereumRequest": {
             "type": "object",
             "properties": {
                 "signature": {
@@ -452,7 +958,7 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_controllers.ErrorResponse": {
+        "controllers.ErrorResponse": {
             "type": "object",
             "properties": {
                 "errorMessage": {
@@ -460,7 +966,102 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_controllers.SubmitReferralCodeRequest": {
+        "controllers.ExportJobResponse": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "description": "CompletedAt is when the export was built, or failed to be.",
+                    "type": "string",
+                    "example": "2021-12-01T09:00:04Z"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is when a finished export stops being available for download.",
+                    "type": "string",
+                    "example": "2021-12-08T09:00:04Z"
+                },
+                "format": {
+                    "description": "Format is either \"json\" or \"zip\".",
+                    "type": "string",
+                    "example": "zip"
+                },
+                "id": {
+                    "description": "ID identifies the export in GET /v1/user/export/{exportId}.",
+                    "type": "string",
+                    "example": "2Dg3bIWAPOeUWyGJh0Z9u40fJKl"
+                },
+                "status": {
+                    "description": "Status is one of \"pending\", \"done\", \"failed\" or \"expired\".",
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "controllers.ReferralEntry": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID is the referred user's ID with most characters masked out.",
+                    "type": "string",
+                    "example": "Chf****Rsb"
+                },
+                "referredAt": {
+                    "description": "ReferredAt is when the referred user entered the referral code.",
+                    "type": "string",
+                    "example": "2021-12-01T09:00:41Z"
+                },
+                "web3Confirmed": {
+                    "description": "Web3Confirmed indicates whether the referred user has confirmed an Ethereum address.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "web3Used": {
+                    "description": "Web3Used indicates whether the referred user has used their address on-chain, as far\nas we've recorded.",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "controllers.ReferralsResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "description": "Page is the 1-based page number of Referrals.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "description": "PageSize is the maximum number of entries in Referrals.",
+                    "type": "integer",
+                    "example": 20
+                },
+                "referrals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.ReferralEntry"
+                    }
+                },
+                "total": {
+                    "description": "Total is the number of users who have entered this user's referral code.",
+                    "type": "integer",
+                    "example": 12
+                },
+                "walletConfirmed": {
+                    "description": "WalletConfirmed is the number of referred users with a confirmed Ethereum address.",
+                    "type": "integer",
+                    "example": 9
+                },
+                "web3Used": {
+                    "description": "Web3Used is the number of referred users who have used their address on-chain.",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "controllers.SubmitReferralCodeRequest": {
             "type": "object",
             "properties": {
                 "referralCode": {
@@ -470,7 +1071,7 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_controllers.SubmitReferralCodeResponse": {
+        "controllers.SubmitReferralCodeResponse": {
             "type": "object",
             "properties": {
                 "message": {
@@ -478,7 +1079,7 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_controllers.UserResponse": {
+        "controllers.UserResponse": {
             "type": "object",
             "properties": {
                 "agreedTosAt": {
@@ -486,6 +1087,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2021-12-01T09:00:41Z"
                 },
+                "agreedTosVersion": {
+                    "description": "AgreedTOSVersion is the version of the terms of service that the user last agreed to.",
+                    "type": "string",
+                    "example": "2024-01"
+                },
                 "countryCode": {
                     "description": "CountryCode, if present, is a valid ISO 3166-1 alpha-3 country code.",
                     "type": "string",
@@ -500,7 +1106,7 @@ const docTemplate = `{
                     "description": "Email describes the user's email and the state of its confirmation.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/internal_controllers.UserResponseEmail"
+                            "$ref": "#/definitions/controllers.UserResponseEmail"
                         }
                     ]
                 },
@@ -514,7 +1120,7 @@ const docTemplate = `{
                     "example": "2024-09-17T09:00:00Z"
                 },
                 "referralCode": {
-                    "description": "ReferralCode is the user's referral code to be given to others. It is an 8 alphanumeric code,\nonly present if the account has a confirmed Ethereum address.",
+                    "description": "ReferralCode is the user's referral code to be given to others. It is a 6-character alphanumeric\ncode, only present if the account has a confirmed Ethereum address.",
                     "type": "string",
                     "example": "ANB95N"
                 },
@@ -526,17 +1132,22 @@ const docTemplate = `{
                     "type": "string",
                     "example": "0x3497B704a954789BC39999262510DE9B09Ff1366"
                 },
+                "tosOutdated": {
+                    "description": "TOSOutdated is true if the user has not agreed to the current terms of service. Only\ncomputed by /v2/user.",
+                    "type": "boolean",
+                    "example": false
+                },
                 "web3": {
                     "description": "Web3 describes the user's blockchain account.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/internal_controllers.UserResponseWeb3"
+                            "$ref": "#/definitions/controllers.UserResponseWeb3"
                         }
                     ]
                 }
             }
         },
-        "internal_controllers.UserResponseEmail": {
+        "controllers.UserResponseEmail": {
             "type": "object",
             "properties": {
                 "address": {
@@ -556,7 +1167,7 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_controllers.UserResponseWeb3": {
+        "controllers.UserResponseWeb3": {
             "type": "object",
             "properties": {
                 "address": {
@@ -586,40 +1197,85 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_controllers.UserUpdateRequest": {
+        "controllers.UserUpdateRequest": {
             "type": "object",
             "properties": {
                 "countryCode": {
-                    "description": "CountryCode, if specified, should be a valid ISO 3166-1 alpha-3 country code",
+                    "description": "CountryCode, if specified, should be a valid ISO 3166-1 alpha-3 country code. An empty\nstring clears the field.",
                     "type": "string",
                     "example": "USA"
                 },
                 "email": {
-                    "type": "object",
-                    "properties": {
-                        "address": {
-                            "description": "Address, if present, should be a valid email address. Note when this field\nis modified the user's verification status will reset.",
-                            "type": "string",
-                            "example": "neal@dimo.zone"
+                    "description": "Email, if present, specifies changes to the user's email.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/controllers.UserUpdateRequestEmail"
                         }
-                    }
+                    ]
+                }
+            }
+        },
+        "controllers.UserUpdateRequestEmail": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address, if present, should be a valid email address. Note when this field\nis modified the user's verification status will reset.",
+                    "type": "string",
+                    "example": "neal@dimo.zone"
+                }
+            }
+        },
+        "controllers.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "errorMessage": {
+                    "type": "string"
                 },
-                "web3": {
+                "fields": {
+                    "description": "Fields maps JSON paths of the offending fields to a description of the problem.",
                     "type": "object",
-                    "properties": {
-                        "address": {
-                            "description": "Address, if present, should be a valid ethereum address. Note when this field\nis modified the user's address verification status will reset.",
-                            "type": "string",
-                            "example": "0x71C7656EC7ab88b098defB751B7401B5f6d8976F"
-                        },
-                        "inApp": {
-                            "description": "InApp, if true, indicates that the address above corresponds to an in-app wallet.\nYou can only set this when setting a new wallet. It defaults to false.",
-                            "type": "boolean",
-                            "example": true
-                        }
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "countryCode": "must be a valid ISO 3166-1 alpha-3 country code"
                     }
                 }
             }
+        },
+        "controllers.WalletResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address is the wallet's Ethereum address.",
+                    "type": "string",
+                    "example": "0x142e0C7A098622Ea98E5D67034251C4dFA746B5d"
+                },
+                "confirmedAt": {
+                    "description": "ConfirmedAt is when the user proved ownership of the wallet by signing a challenge.\nUnconfirmed wallets are not used to look the user up.",
+                    "type": "string",
+                    "example": "2021-12-01T09:01:12Z"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                },
+                "kind": {
+                    "description": "Kind is either \"external\" or \"in_app\", for wallets managed by the DIMO app.",
+                    "type": "string",
+                    "example": "external"
+                },
+                "label": {
+                    "description": "Label is a name the user has given the wallet.",
+                    "type": "string",
+                    "example": "Hardware wallet"
+                },
+                "primary": {
+                    "description": "Primary is true for the wallet reported as the user's address in /v1/user.",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
         }
     },
     "securityDefinitions": {
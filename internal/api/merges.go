@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DIMO-Network/users-api/internal/services"
+	pb "github.com/DIMO-Network/users-api/pkg/grpc"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func formatMergePlan(plan *services.MergePlan) *pb.UserMergePlan {
+	out := &pb.UserMergePlan{
+		EthereumAddress:    plan.EthereumAddress.Bytes(),
+		SurvivorId:         plan.SurvivorID,
+		MergedIds:          plan.MergedIDs,
+		EmailAddress:       plan.Email.Value.Ptr(),
+		EmailFromId:        plan.Email.From,
+		ReferralCode:       plan.ReferralCode.Value.Ptr(),
+		ReferralCodeFromId: plan.ReferralCode.From,
+		ReferringUserId:    plan.Referrer.Value.Ptr(),
+		ReferrerFromId:     plan.Referrer.From,
+		AgreedTosFromId:    plan.AgreedTOSAt.From,
+		MigratedFromId:     plan.MigratedAt.From,
+		CountryCode:        plan.CountryCode.Value.Ptr(),
+		CountryCodeFromId:  plan.CountryCode.From,
+	}
+
+	if plan.AgreedTOSAt.Value.Valid {
+		out.AgreedTosAt = timestamppb.New(plan.AgreedTOSAt.Value.Time)
+	}
+
+	if plan.MigratedAt.Value.Valid {
+		out.MigratedAt = timestamppb.New(plan.MigratedAt.Value.Time)
+	}
+
+	return out
+}
+
+func (s *userService) ListUserMergePlans(ctx context.Context, in *pb.ListUserMergePlansRequest) (*pb.ListUserMergePlansResponse, error) {
+	var addrs []common.Address
+
+	if in.EthereumAddress != nil {
+		if len(in.EthereumAddress) != common.AddressLength {
+			return nil, status.Error(codes.InvalidArgument, "Ethereum address must be 20 bytes.")
+		}
+		addrs = []common.Address{common.BytesToAddress(in.EthereumAddress)}
+	} else {
+		var err error
+		addrs, err = services.DuplicateAddresses(ctx, s.dbs.DBS().Reader)
+		if err != nil {
+			s.logger.Err(err).Msg("Database failure finding duplicate accounts.")
+			return nil, status.Error(codes.Internal, "Internal error.")
+		}
+	}
+
+	var out pb.ListUserMergePlansResponse
+
+	for _, addr := range addrs {
+		plan, err := services.PlanMerge(ctx, s.dbs.DBS().Reader, addr)
+		if err != nil {
+			if errors.Is(err, services.ErrNoDuplicates) {
+				continue
+			}
+			s.logger.Err(err).Str("ethAddr", addr.Hex()).Msg("Database failure planning merge.")
+			return nil, status.Error(codes.Internal, "Internal error.")
+		}
+		out.Plans = append(out.Plans, formatMergePlan(plan))
+	}
+
+	return &out, nil
+}
+
+func (s *userService) MergeUsers(ctx context.Context, in *pb.MergeUsersRequest) (*pb.UserMergePlan, error) {
+	if len(in.EthereumAddress) != common.AddressLength {
+		return nil, status.Error(codes.InvalidArgument, "Ethereum address must be 20 bytes.")
+	}
+	if in.Actor == "" {
+		return nil, status.Error(codes.InvalidArgument, "Actor is required.")
+	}
+
+	addr := common.BytesToAddress(in.EthereumAddress)
+
+	plan, err := services.MergeDuplicates(ctx, s.dbs, addr, in.Actor, in.DryRun)
+	if err != nil {
+		if errors.Is(err, services.ErrNoDuplicates) {
+			return nil, status.Error(codes.NotFound, "No duplicate accounts for that address.")
+		}
+		s.logger.Err(err).Str("ethAddr", addr.Hex()).Msg("Failed to merge accounts.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	if !in.DryRun {
+		s.logger.Info().Str("ethAddr", addr.Hex()).Str("survivorId", plan.SurvivorID).Strs("mergedIds", plan.MergedIDs).Str("actor", in.Actor).Msg("Merged accounts.")
+	}
+
+	return formatMergePlan(plan), nil
+}
@@ -3,33 +3,53 @@ package api
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"errors"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/users-api/internal/services"
 	"github.com/DIMO-Network/users-api/models"
 	pb "github.com/DIMO-Network/users-api/pkg/grpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
 	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func NewUserService(dbs db.Store, logger *zerolog.Logger) pb.UserServiceServer {
-	return &userService{dbs: dbs, logger: logger}
+const (
+	// maxGetUsersBatch caps the number of IDs in a single GetUsers call.
+	maxGetUsersBatch = 500
+	// maxAddressBatch caps the number of addresses in a single GetUsersByEthereumAddresses call.
+	maxAddressBatch = 1000
+	// listUsersPageSize is the number of rows ListUsers fetches per query.
+	listUsersPageSize = 500
+)
+
+func NewUserService(dbs db.Store, devicesClient services.DevicesAPI, logger *zerolog.Logger) pb.UserServiceServer {
+	return &userService{dbs: dbs, devicesClient: devicesClient, logger: logger}
 }
 
 type userService struct {
 	pb.UnimplementedUserServiceServer
-	dbs    db.Store
-	logger *zerolog.Logger
+	dbs           db.Store
+	devicesClient services.DevicesAPI
+	logger        *zerolog.Logger
 }
 
 func (s *userService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
 	dbUser, err := models.Users(
 		models.UserWhere.ID.EQ(req.Id),
-		qm.Load(models.UserRels.ReferringUser),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()),
 	).One(ctx, s.dbs.DBS().Reader)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -42,9 +62,57 @@ func (s *userService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.
 	return formatUser(dbUser), nil
 }
 
+func (s *userService) ResolveIdentity(ctx context.Context, in *pb.ResolveIdentityRequest) (*pb.User, error) {
+	if in.ProviderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Provider ID is required.")
+	}
+	if in.Subject == "" {
+		return nil, status.Error(codes.InvalidArgument, "Subject is required.")
+	}
+
+	userID, err := services.ResolveIdentity(ctx, s.dbs.DBS().Reader, in.ProviderId, in.Subject)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.logger.Err(err).Str("subject", in.Subject).Msg("Database failure resolving identity.")
+			return nil, status.Error(codes.Internal, "Internal error.")
+		}
+		userID = in.Subject
+	}
+
+	dbUser, err := models.Users(
+		models.UserWhere.ID.EQ(userID),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()),
+	).One(ctx, s.dbs.DBS().Reader)
+	if err == nil {
+		dbUser, err = services.ResolveMerged(ctx, s.dbs.DBS().Reader, dbUser, qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()))
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "No user for that identity.")
+		}
+		s.logger.Err(err).Str("userId", userID).Msg("Database failure retrieving user.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	return formatUser(dbUser), nil
+}
+
 func formatUser(user *models.User) *pb.User {
 	out := pb.User{
-		Id: user.ID,
+		Id:             user.ID,
+		CountryCode:    user.CountryCode.Ptr(),
+		InAppWallet:    user.InAppWallet,
+		AuthProviderId: user.AuthProviderID,
+		CreatedAt:      timestamppb.New(user.CreatedAt),
+	}
+
+	if user.AgreedTosAt.Valid {
+		out.AgreedTosAt = timestamppb.New(user.AgreedTosAt.Time)
+	}
+
+	if user.MigratedAt.Valid {
+		out.MigratedAt = timestamppb.New(user.MigratedAt.Time)
 	}
 
 	if user.EthereumConfirmed {
@@ -74,9 +142,10 @@ func formatUser(user *models.User) *pb.User {
 
 func (s *userService) GetUserByEthAddr(ctx context.Context, req *pb.GetUserByEthRequest) (*pb.User, error) {
 	dbUser, err := models.Users(
-		models.UserWhere.EthereumAddress.EQ(null.BytesFrom(req.EthAddr)),
-		models.UserWhere.EthereumConfirmed.EQ(true),
-		qm.Load(models.UserRels.ReferringUser),
+		services.ConfirmedAddressIn(req.EthAddr),
+		models.UserWhere.MergedIntoID.IsNull(),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()),
 	).One(ctx, s.dbs.DBS().Reader)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -91,9 +160,10 @@ func (s *userService) GetUserByEthAddr(ctx context.Context, req *pb.GetUserByEth
 
 func (s *userService) GetUsersByEthereumAddress(ctx context.Context, in *pb.GetUsersByEthereumAddressRequest) (*pb.GetUsersByEthereumAddressResponse, error) {
 	users, err := models.Users(
-		models.UserWhere.EthereumConfirmed.EQ(true),
-		models.UserWhere.EthereumAddress.EQ(null.BytesFrom(in.EthereumAddress)),
-		qm.Load(models.UserRels.ReferringUser),
+		models.UserWhere.MergedIntoID.IsNull(),
+		services.ConfirmedAddressIn(in.EthereumAddress),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()),
 		qm.OrderBy(models.UserColumns.CreatedAt+" DESC"),
 	).All(ctx, s.dbs.DBS().Reader)
 	if err != nil {
@@ -108,3 +178,354 @@ func (s *userService) GetUsersByEthereumAddress(ctx context.Context, in *pb.GetU
 
 	return &out, nil
 }
+
+func (s *userService) GetUsers(ctx context.Context, in *pb.GetUsersRequest) (*pb.GetUsersResponse, error) {
+	if len(in.Ids) > maxGetUsersBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "At most %d ids may be requested at once.", maxGetUsersBatch)
+	}
+
+	// Deduplicate, keeping the first occurrence.
+	seen := make(map[string]struct{}, len(in.Ids))
+	ids := make([]string, 0, len(in.Ids))
+	for _, id := range in.Ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	var out pb.GetUsersResponse
+
+	if len(ids) == 0 {
+		return &out, nil
+	}
+
+	users, err := models.Users(
+		models.UserWhere.ID.IN(ids),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()),
+	).All(ctx, s.dbs.DBS().Reader)
+	if err != nil {
+		s.logger.Err(err).Int("count", len(ids)).Msg("Database failure retrieving users.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	byID := make(map[string]*models.User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+
+	for _, id := range ids {
+		if u, ok := byID[id]; ok {
+			out.Users = append(out.Users, formatUser(u))
+		} else {
+			out.MissingIds = append(out.MissingIds, id)
+		}
+	}
+
+	return &out, nil
+}
+
+func (s *userService) GetUsersByEthereumAddresses(ctx context.Context, in *pb.GetUsersByEthereumAddressesRequest) (*pb.GetUsersByEthereumAddressesResponse, error) {
+	if len(in.EthereumAddresses) > maxAddressBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "At most %d addresses may be requested at once.", maxAddressBatch)
+	}
+
+	var out pb.GetUsersByEthereumAddressesResponse
+
+	entries := make(map[common.Address]*pb.EthereumAddressUsers, len(in.EthereumAddresses))
+	args := make([]any, 0, len(in.EthereumAddresses))
+
+	for _, b := range in.EthereumAddresses {
+		if len(b) != common.AddressLength {
+			return nil, status.Errorf(codes.InvalidArgument, "Address 0x%x is not %d bytes long.", b, common.AddressLength)
+		}
+		addr := common.BytesToAddress(b)
+		if _, ok := entries[addr]; ok {
+			continue
+		}
+		e := &pb.EthereumAddressUsers{EthereumAddress: addr.Bytes()}
+		entries[addr] = e
+		out.Entries = append(out.Entries, e)
+		args = append(args, addr.Bytes())
+	}
+
+	if len(args) == 0 {
+		return &out, nil
+	}
+
+	users, err := models.Users(
+		models.UserWhere.MergedIntoID.IsNull(),
+		services.ConfirmedAddressIn(args...),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()),
+		qm.Load(models.UserRels.UserWallets,
+			models.UserWalletWhere.ConfirmedAt.IsNotNull(),
+			qm.WhereIn(models.UserWalletColumns.Address+" IN ?", args...),
+		),
+		qm.OrderBy(models.UserColumns.CreatedAt+" DESC"),
+	).All(ctx, s.dbs.DBS().Reader)
+	if err != nil {
+		s.logger.Err(err).Int("count", len(args)).Msg("Database failure retrieving users by address.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	for _, u := range users {
+		// A user appears once under each requested address they hold.
+		addrs := make(map[common.Address]bool)
+		if u.EthereumConfirmed {
+			addrs[common.BytesToAddress(u.EthereumAddress.Bytes)] = true
+		}
+		for _, w := range u.R.GetUserWallets() {
+			addrs[common.BytesToAddress(w.Address)] = true
+		}
+
+		pu := formatUser(u)
+		for addr := range addrs {
+			if e, ok := entries[addr]; ok {
+				e.Users = append(e.Users, pu)
+			}
+		}
+	}
+
+	return &out, nil
+}
+
+// encodeListCursor packs the keyset position of a user into an opaque string.
+func encodeListCursor(user *models.User) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(user.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + user.ID))
+}
+
+func decodeListCursor(cursor string) (time.Time, string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	rawTime, id, ok := strings.Cut(string(b), "|")
+	if !ok {
+		return time.Time{}, "", errors.New("missing separator")
+	}
+	t, err := time.Parse(time.RFC3339Nano, rawTime)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return t, id, nil
+}
+
+func (s *userService) ListUsers(in *pb.ListUsersRequest, stream grpc.ServerStreamingServer[pb.ListUsersResponse]) error {
+	ctx := stream.Context()
+
+	mods := []qm.QueryMod{models.UserWhere.DeletedAt.IsNull()}
+
+	if in.EthereumConfirmed != nil {
+		mods = append(mods, models.UserWhere.EthereumConfirmed.EQ(*in.EthereumConfirmed))
+	}
+	if in.EmailConfirmed != nil {
+		mods = append(mods, models.UserWhere.EmailConfirmed.EQ(*in.EmailConfirmed))
+	}
+	if in.InAppWallet != nil {
+		mods = append(mods, models.UserWhere.InAppWallet.EQ(*in.InAppWallet))
+	}
+	if in.AuthProviderId != nil {
+		mods = append(mods, models.UserWhere.AuthProviderID.EQ(*in.AuthProviderId))
+	}
+	if in.Migrated != nil {
+		if *in.Migrated {
+			mods = append(mods, models.UserWhere.MigratedAt.IsNotNull())
+		} else {
+			mods = append(mods, models.UserWhere.MigratedAt.IsNull())
+		}
+	}
+	if in.CreatedAfter != nil {
+		mods = append(mods, models.UserWhere.CreatedAt.GTE(in.CreatedAfter.AsTime()))
+	}
+	if in.CreatedBefore != nil {
+		mods = append(mods, models.UserWhere.CreatedAt.LT(in.CreatedBefore.AsTime()))
+	}
+
+	var (
+		afterTime time.Time
+		afterID   string
+		hasAfter  bool
+	)
+
+	if in.Cursor != "" {
+		var err error
+		afterTime, afterID, err = decodeListCursor(in.Cursor)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "Invalid cursor.")
+		}
+		hasAfter = true
+	}
+
+	for {
+		pageMods := append([]qm.QueryMod{}, mods...)
+		if hasAfter {
+			pageMods = append(pageMods, qm.Where("("+models.UserColumns.CreatedAt+", "+models.UserColumns.ID+") > (?, ?)", afterTime, afterID))
+		}
+		pageMods = append(pageMods,
+			qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()),
+			qm.OrderBy(models.UserColumns.CreatedAt+", "+models.UserColumns.ID),
+			qm.Limit(listUsersPageSize),
+		)
+
+		users, err := models.Users(pageMods...).All(ctx, s.dbs.DBS().Reader)
+		if err != nil {
+			s.logger.Err(err).Msg("Database failure listing users.")
+			return status.Error(codes.Internal, "Internal error.")
+		}
+
+		for _, u := range users {
+			if err := stream.Send(&pb.ListUsersResponse{User: formatUser(u), Cursor: encodeListCursor(u)}); err != nil {
+				return err
+			}
+		}
+
+		if len(users) < listUsersPageSize {
+			return nil
+		}
+
+		last := users[len(users)-1]
+		afterTime, afterID, hasAfter = last.CreatedAt, last.ID, true
+	}
+}
+
+const (
+	updateMaskCountryCode = "country_code"
+	updateMaskMigratedAt  = "migrated_at"
+)
+
+func (s *userService) UpdateUser(ctx context.Context, in *pb.UpdateUserRequest) (*pb.User, error) {
+	if in.User == nil || in.User.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "User id is required.")
+	}
+
+	paths := in.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Update mask is empty.")
+	}
+
+	countryCode := strings.ToUpper(strings.TrimSpace(in.User.GetCountryCode()))
+
+	for _, p := range paths {
+		switch p {
+		case updateMaskCountryCode:
+			if countryCode != "" && !services.ValidCountryCode(countryCode) {
+				return nil, status.Errorf(codes.InvalidArgument, "Country code %q is not a valid ISO 3166-1 alpha-3 code.", countryCode)
+			}
+		case updateMaskMigratedAt:
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "Path %q can't be updated.", p)
+		}
+	}
+
+	tx, err := s.dbs.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to begin transaction.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+	defer tx.Rollback() //nolint
+
+	dbUser, err := models.Users(
+		models.UserWhere.ID.EQ(in.User.Id),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "No user with that ID found.")
+		}
+		s.logger.Err(err).Str("userId", in.User.Id).Msg("Database failure retrieving user.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	for _, p := range paths {
+		switch p {
+		case updateMaskCountryCode:
+			if countryCode != "" {
+				dbUser.CountryCode = null.StringFrom(countryCode)
+			} else {
+				dbUser.CountryCode = null.StringFromPtr(nil)
+			}
+		case updateMaskMigratedAt:
+			if in.User.MigratedAt != nil {
+				dbUser.MigratedAt = null.TimeFrom(in.User.MigratedAt.AsTime())
+			} else {
+				dbUser.MigratedAt = null.TimeFromPtr(nil)
+			}
+		}
+	}
+
+	if _, err := dbUser.Update(ctx, tx, boil.Infer()); err != nil {
+		s.logger.Err(err).Str("userId", in.User.Id).Msg("Database failure updating user.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	if dbUser.MigratedAt.Valid && slices.Contains(paths, updateMaskMigratedAt) {
+		if err := services.EnqueueUserEvent(ctx, tx, services.UserMigratedEventType, services.UserEventData{
+			UserID:     dbUser.ID,
+			MigratedAt: dbUser.MigratedAt.Ptr(),
+		}); err != nil {
+			s.logger.Err(err).Str("userId", in.User.Id).Msg("Failed to record migration event.")
+			return nil, status.Error(codes.Internal, "Internal error.")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Err(err).Str("userId", in.User.Id).Msg("Failed to commit user update.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	return formatUser(dbUser), nil
+}
+
+func (s *userService) DeleteUser(ctx context.Context, in *pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	tx, err := s.dbs.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to begin transaction.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+	defer tx.Rollback() //nolint
+
+	dbUser, err := services.FindUser(ctx, tx, in.Id, qm.For("UPDATE"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "No user with that ID found.")
+		}
+		s.logger.Err(err).Str("userId", in.Id).Msg("Database failure retrieving user.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	n, err := services.UserDeviceCount(ctx, s.devicesClient, in.Id)
+	if err != nil {
+		s.logger.Err(err).Str("userId", in.Id).Msg("Failed to list user's devices.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	if n > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "User must delete %d devices first.", n)
+	}
+
+	dbUser.DeletedAt = null.TimeFrom(time.Now())
+
+	if _, err := dbUser.Update(ctx, tx, boil.Whitelist(models.UserColumns.DeletedAt)); err != nil {
+		s.logger.Err(err).Str("userId", in.Id).Msg("Database failure deleting user.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	if err := services.EnqueueUserEvent(ctx, tx, services.UserDeletedEventType, services.UserEventData{UserID: in.Id}); err != nil {
+		s.logger.Err(err).Str("userId", in.Id).Msg("Failed to record deletion event.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Err(err).Str("userId", in.Id).Msg("Failed to commit user deletion.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	s.logger.Info().Str("userId", in.Id).Msg("Deleted user.")
+
+	return &emptypb.Empty{}, nil
+}
@@ -3,9 +3,12 @@ package api
 import (
 	"context"
 	"testing"
+	"time"
 
+	dpb "github.com/DIMO-Network/devices-api/pkg/grpc"
 	"github.com/DIMO-Network/shared/db"
 	"github.com/DIMO-Network/users-api/internal/database"
+	"github.com/DIMO-Network/users-api/internal/services"
 	"github.com/DIMO-Network/users-api/models"
 	userpb "github.com/DIMO-Network/users-api/pkg/grpc"
 	"github.com/docker/go-connections/nat"
@@ -16,6 +19,11 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type UserServiceTestSuite struct {
@@ -82,7 +90,7 @@ func (s *UserServiceTestSuite) TearDownSuite() {
 
 func (s *UserServiceTestSuite) TestGetUserByEthAddr() {
 	ctx := context.Background()
-	userSvc := NewUserService(s.dbs, s.logger)
+	userSvc := NewUserService(s.dbs, &devicesAPI{}, s.logger)
 
 	ethAddr := common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
 	testUser := models.User{
@@ -111,3 +119,369 @@ func (s *UserServiceTestSuite) TestGetUserByEthAddr() {
 	_, err = models.Users(models.UserWhere.ID.EQ("TestUserID")).DeleteAll(ctx, s.dbs.DBS().Writer)
 	s.Require().NoError(err)
 }
+
+func (s *UserServiceTestSuite) TestGetUsersByEthereumAddress_Wallets() {
+	ctx := context.Background()
+	userSvc := NewUserService(s.dbs, &devicesAPI{}, s.logger)
+
+	addr := common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
+	other := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	primary := models.User{ID: "Primary", EthereumAddress: null.BytesFrom(addr.Bytes()), EthereumConfirmed: true, CreatedAt: time.Now()}
+	s.Require().NoError(primary.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	secondary := models.User{ID: "Secondary", EthereumAddress: null.BytesFrom(other.Bytes()), EthereumConfirmed: true, CreatedAt: time.Now().Add(-time.Hour)}
+	s.Require().NoError(secondary.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	unconfirmed := models.User{ID: "Unconfirmed", CreatedAt: time.Now()}
+	s.Require().NoError(unconfirmed.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	_, err := services.ConfirmWallet(ctx, s.dbs.DBS().Writer, secondary.ID, addr, services.WalletKindExternal)
+	s.Require().NoError(err)
+
+	wallet := models.UserWallet{UserID: unconfirmed.ID, Address: addr.Bytes(), Kind: services.WalletKindExternal}
+	s.Require().NoError(wallet.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	out, err := userSvc.GetUsersByEthereumAddress(ctx, &userpb.GetUsersByEthereumAddressRequest{EthereumAddress: addr.Bytes()})
+	s.Require().NoError(err)
+	s.Require().Len(out.Users, 2)
+	s.Equal("Primary", out.Users[0].Id)
+	s.Equal("Secondary", out.Users[1].Id)
+	// Still reported with the primary address.
+	s.Equal(other.Hex(), out.Users[1].GetEthereumAddress())
+
+	// The batch lookup finds Secondary under both of its addresses.
+	batch, err := userSvc.GetUsersByEthereumAddresses(ctx, &userpb.GetUsersByEthereumAddressesRequest{EthereumAddresses: [][]byte{addr.Bytes(), other.Bytes()}})
+	s.Require().NoError(err)
+	s.Require().Len(batch.Entries, 2)
+	s.Require().Len(batch.Entries[0].Users, 2)
+	s.Equal("Primary", batch.Entries[0].Users[0].Id)
+	s.Equal("Secondary", batch.Entries[0].Users[1].Id)
+	s.Require().Len(batch.Entries[1].Users, 1)
+	s.Equal("Secondary", batch.Entries[1].Users[0].Id)
+
+	_, err = models.Users(models.UserWhere.ID.EQ(primary.ID)).DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+
+	user, err := userSvc.GetUserByEthAddr(ctx, &userpb.GetUserByEthRequest{EthAddr: addr.Bytes()})
+	s.Require().NoError(err)
+	s.Equal("Secondary", user.Id)
+
+	_, err = models.Users().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+}
+
+func (s *UserServiceTestSuite) TestGetUsers() {
+	ctx := context.Background()
+	userSvc := NewUserService(s.dbs, &devicesAPI{}, s.logger)
+
+	referrerAddr := common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
+	referrer := models.User{
+		ID:                "Referrer",
+		EthereumAddress:   null.BytesFrom(referrerAddr.Bytes()),
+		EthereumConfirmed: true,
+	}
+	s.Require().NoError(referrer.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	referee := models.User{
+		ID:              "Referee",
+		ReferringUserID: null.StringFrom("Referrer"),
+		ReferredAt:      null.TimeFrom(time.Now()),
+	}
+	s.Require().NoError(referee.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	resp, err := userSvc.GetUsers(ctx, &userpb.GetUsersRequest{Ids: []string{"Referee", "Ghost", "Referrer", "Referee"}})
+	s.Require().NoError(err)
+
+	s.Require().Len(resp.Users, 2)
+	s.Equal("Referee", resp.Users[0].Id)
+	s.Require().NotNil(resp.Users[0].ReferredBy)
+	s.True(resp.Users[0].ReferredBy.ReferrerValid)
+	s.Equal("Referrer", resp.Users[0].ReferredBy.Id)
+	s.Equal("Referrer", resp.Users[1].Id)
+	s.Equal([]string{"Ghost"}, resp.MissingIds)
+
+	_, err = userSvc.GetUsers(ctx, &userpb.GetUsersRequest{Ids: make([]string, maxGetUsersBatch+1)})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = models.Users().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+}
+
+func (s *UserServiceTestSuite) TestGetUsersByEthereumAddresses() {
+	ctx := context.Background()
+	userSvc := NewUserService(s.dbs, &devicesAPI{}, s.logger)
+
+	addr1 := common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
+	addr2 := common.HexToAddress("0xabcdef1234567890abcdef1234567890abcdef12")
+	addr3 := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	for _, u := range []models.User{
+		{ID: "Old", EthereumAddress: null.BytesFrom(addr1.Bytes()), EthereumConfirmed: true, CreatedAt: time.Now().Add(-time.Hour)},
+		{ID: "New", EthereumAddress: null.BytesFrom(addr1.Bytes()), EthereumConfirmed: true, CreatedAt: time.Now()},
+		{ID: "Unconfirmed", EthereumAddress: null.BytesFrom(addr2.Bytes()), CreatedAt: time.Now()},
+	} {
+		s.Require().NoError(u.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+	}
+
+	resp, err := userSvc.GetUsersByEthereumAddresses(ctx, &userpb.GetUsersByEthereumAddressesRequest{
+		EthereumAddresses: [][]byte{addr2.Bytes(), addr1.Bytes(), addr3.Bytes(), addr1.Bytes()},
+	})
+	s.Require().NoError(err)
+
+	s.Require().Len(resp.Entries, 3)
+	s.Equal(addr2.Bytes(), resp.Entries[0].EthereumAddress)
+	s.Empty(resp.Entries[0].Users)
+	s.Equal(addr1.Bytes(), resp.Entries[1].EthereumAddress)
+	s.Require().Len(resp.Entries[1].Users, 2)
+	s.Equal("New", resp.Entries[1].Users[0].Id)
+	s.Equal("Old", resp.Entries[1].Users[1].Id)
+	s.Empty(resp.Entries[2].Users)
+
+	_, err = userSvc.GetUsersByEthereumAddresses(ctx, &userpb.GetUsersByEthereumAddressesRequest{
+		EthereumAddresses: [][]byte{{1, 2, 3}},
+	})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = models.Users().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+}
+
+type listUsersStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*userpb.ListUsersResponse
+}
+
+func (s *listUsersStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *listUsersStream) Send(r *userpb.ListUsersResponse) error {
+	s.responses = append(s.responses, r)
+	return nil
+}
+
+func (s *UserServiceTestSuite) TestListUsers() {
+	ctx := context.Background()
+	userSvc := NewUserService(s.dbs, &devicesAPI{}, s.logger)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, u := range []models.User{
+		{ID: "B", CreatedAt: base, EthereumConfirmed: true},
+		{ID: "A", CreatedAt: base, EthereumConfirmed: true, MigratedAt: null.TimeFrom(base)},
+		{ID: "C", CreatedAt: base.Add(time.Hour)},
+		{ID: "D", CreatedAt: base.Add(2 * time.Hour), EthereumConfirmed: true},
+	} {
+		s.Require().NoError(u.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+	}
+
+	ids := func(st *listUsersStream) []string {
+		var out []string
+		for _, r := range st.responses {
+			out = append(out, r.User.Id)
+		}
+		return out
+	}
+
+	st := &listUsersStream{ctx: ctx}
+	s.Require().NoError(userSvc.ListUsers(&userpb.ListUsersRequest{}, st))
+	s.Equal([]string{"A", "B", "C", "D"}, ids(st))
+
+	confirmed := true
+	st = &listUsersStream{ctx: ctx}
+	s.Require().NoError(userSvc.ListUsers(&userpb.ListUsersRequest{
+		EthereumConfirmed: &confirmed,
+		CreatedBefore:     timestamppb.New(base.Add(2 * time.Hour)),
+	}, st))
+	s.Equal([]string{"A", "B"}, ids(st))
+
+	migrated := false
+	st = &listUsersStream{ctx: ctx}
+	s.Require().NoError(userSvc.ListUsers(&userpb.ListUsersRequest{Migrated: &migrated}, st))
+	s.Equal([]string{"B", "C", "D"}, ids(st))
+
+	// Resume after the second row of the full listing.
+	full := &listUsersStream{ctx: ctx}
+	s.Require().NoError(userSvc.ListUsers(&userpb.ListUsersRequest{}, full))
+
+	st = &listUsersStream{ctx: ctx}
+	s.Require().NoError(userSvc.ListUsers(&userpb.ListUsersRequest{Cursor: full.responses[1].Cursor}, st))
+	s.Equal([]string{"C", "D"}, ids(st))
+
+	err := userSvc.ListUsers(&userpb.ListUsersRequest{Cursor: "garbage"}, &listUsersStream{ctx: ctx})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = models.Users().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+}
+
+func (s *UserServiceTestSuite) TestGetUser_Attributes() {
+	ctx := context.Background()
+	userSvc := NewUserService(s.dbs, &devicesAPI{}, s.logger)
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	migrated := created.Add(time.Hour)
+
+	u := models.User{
+		ID:             "Attrs",
+		CountryCode:    null.StringFrom("USA"),
+		AuthProviderID: "google",
+		InAppWallet:    true,
+		CreatedAt:      created,
+		MigratedAt:     null.TimeFrom(migrated),
+	}
+	s.Require().NoError(u.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	out, err := userSvc.GetUser(ctx, &userpb.GetUserRequest{Id: "Attrs"})
+	s.Require().NoError(err)
+
+	s.Equal("USA", out.GetCountryCode())
+	s.Equal("google", out.AuthProviderId)
+	s.True(out.InAppWallet)
+	s.True(created.Equal(out.CreatedAt.AsTime()))
+	s.True(migrated.Equal(out.MigratedAt.AsTime()))
+	s.Nil(out.AgreedTosAt)
+
+	_, err = models.Users().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+}
+
+func (s *UserServiceTestSuite) TestResolveIdentity() {
+	ctx := context.Background()
+	userSvc := NewUserService(s.dbs, &devicesAPI{}, s.logger)
+
+	canonical := models.User{ID: "Canonical", AuthProviderID: "google", CreatedAt: time.Now()}
+	s.Require().NoError(canonical.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	other := models.User{ID: "Other", AuthProviderID: "apple", CreatedAt: time.Now()}
+	s.Require().NoError(other.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	identity := models.UserIdentity{ProviderID: "apple", Subject: "Other", UserID: "Canonical"}
+	s.Require().NoError(identity.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	out, err := userSvc.ResolveIdentity(ctx, &userpb.ResolveIdentityRequest{ProviderId: "apple", Subject: "Other"})
+	s.Require().NoError(err)
+	s.Equal("Canonical", out.Id)
+
+	// Subjects are only unique per provider.
+	_, err = userSvc.ResolveIdentity(ctx, &userpb.ResolveIdentityRequest{Subject: "Other"})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+
+	// No identity, so the subject is the user.
+	out, err = userSvc.ResolveIdentity(ctx, &userpb.ResolveIdentityRequest{ProviderId: "google", Subject: "Canonical"})
+	s.Require().NoError(err)
+	s.Equal("Canonical", out.Id)
+
+	_, err = userSvc.ResolveIdentity(ctx, &userpb.ResolveIdentityRequest{ProviderId: "google", Subject: "Missing"})
+	s.Equal(codes.NotFound, status.Code(err))
+
+	_, err = models.Users().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+}
+
+type devicesAPI struct {
+	store map[string][]*dpb.UserDevice
+}
+
+func (d *devicesAPI) ListUserDevicesForUser(_ context.Context, in *dpb.ListUserDevicesForUserRequest, _ ...grpc.CallOption) (*dpb.ListUserDevicesForUserResponse, error) {
+	return &dpb.ListUserDevicesForUserResponse{UserDevices: d.store[in.UserId]}, nil
+}
+
+func (s *UserServiceTestSuite) TestUpdateUser() {
+	ctx := context.Background()
+	userSvc := NewUserService(s.dbs, &devicesAPI{}, s.logger)
+
+	u := models.User{
+		ID:          "Update",
+		CountryCode: null.StringFrom("USA"),
+	}
+	s.Require().NoError(u.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	migrated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := userSvc.UpdateUser(ctx, &userpb.UpdateUserRequest{
+		User:       &userpb.User{Id: "Update"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email_address"}},
+	})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+
+	cc := "XYZ"
+	_, err = userSvc.UpdateUser(ctx, &userpb.UpdateUserRequest{
+		User:       &userpb.User{Id: "Update", CountryCode: &cc},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"country_code"}},
+	})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+
+	// Country code isn't in the mask, so it's left alone.
+	out, err := userSvc.UpdateUser(ctx, &userpb.UpdateUserRequest{
+		User:       &userpb.User{Id: "Update", MigratedAt: timestamppb.New(migrated)},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"migrated_at"}},
+	})
+	s.Require().NoError(err)
+	s.Equal("USA", out.GetCountryCode())
+	s.True(migrated.Equal(out.MigratedAt.AsTime()))
+
+	s.Require().NoError(u.Reload(ctx, s.dbs.DBS().Reader))
+	s.True(migrated.Equal(u.MigratedAt.Time))
+
+	events, err := models.Outboxes(models.OutboxWhere.UserID.EQ("Update")).All(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1)
+	s.Equal(services.UserMigratedEventType, events[0].EventType)
+
+	// Country codes are normalized as they are over REST.
+	cc = " can "
+	out, err = userSvc.UpdateUser(ctx, &userpb.UpdateUserRequest{
+		User:       &userpb.User{Id: "Update", CountryCode: &cc},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"country_code"}},
+	})
+	s.Require().NoError(err)
+	s.Equal("CAN", out.GetCountryCode())
+
+	_, err = models.Users().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+	_, err = models.Outboxes().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+}
+
+func (s *UserServiceTestSuite) TestDeleteUser() {
+	ctx := context.Background()
+
+	devices := &devicesAPI{store: map[string][]*dpb.UserDevice{
+		"Delete": {{Id: "2OQjmqUt9dguQbJt1WImuVfje3W"}},
+	}}
+	userSvc := NewUserService(s.dbs, devices, s.logger)
+
+	u := models.User{ID: "Delete"}
+	s.Require().NoError(u.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	_, err := userSvc.DeleteUser(ctx, &userpb.DeleteUserRequest{Id: "Delete"})
+	s.Equal(codes.FailedPrecondition, status.Code(err))
+
+	delete(devices.store, "Delete")
+
+	_, err = userSvc.DeleteUser(ctx, &userpb.DeleteUserRequest{Id: "Delete"})
+	s.Require().NoError(err)
+
+	// Deleted users are kept for the grace period, but can't be read.
+	s.Require().NoError(u.Reload(ctx, s.dbs.DBS().Reader))
+	s.True(u.DeletedAt.Valid)
+
+	_, err = userSvc.GetUser(ctx, &userpb.GetUserRequest{Id: "Delete"})
+	s.Equal(codes.NotFound, status.Code(err))
+
+	users, err := userSvc.GetUsers(ctx, &userpb.GetUsersRequest{Ids: []string{"Delete"}})
+	s.Require().NoError(err)
+	s.Equal([]string{"Delete"}, users.MissingIds)
+
+	_, err = userSvc.DeleteUser(ctx, &userpb.DeleteUserRequest{Id: "Delete"})
+	s.Equal(codes.NotFound, status.Code(err))
+
+	_, err = models.Users().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+
+	_, err = models.Outboxes().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+}
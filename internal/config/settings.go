@@ -16,9 +16,66 @@ type Settings struct {
 	MonitoringPort     string      `yaml:"MON_PORT"`
 	DevicesAPIGRPCAddr string      `yaml:"DEVICES_API_GRPC_ADDR"`
 
+	// TOSVersion identifies the current terms of service. Users who last agreed to a
+	// different version must agree again.
+	TOSVersion string `yaml:"TOS_VERSION"`
+
+	EmailHost     string `yaml:"EMAIL_HOST"`
+	EmailPort     string `yaml:"EMAIL_PORT"`
+	EmailUsername string `yaml:"EMAIL_USERNAME"`
+	EmailPassword string `yaml:"EMAIL_PASSWORD"`
+	EmailFrom     string `yaml:"EMAIL_FROM"`
+	// EmailFoldPlus strips "+tag" suffixes from email addresses before storing or looking
+	// them up. Lookups also strip them from addresses stored before it was turned on.
+	EmailFoldPlus bool `yaml:"EMAIL_FOLD_PLUS"`
+	// EmailConfirmationTTL is how long, in seconds, a confirmation code can be used. Zero
+	// gets a default of 15 minutes.
+	EmailConfirmationTTL int `yaml:"EMAIL_CONFIRMATION_TTL"`
+	// ConfirmationEmailRate limits confirmation emails per user, as an average per hour
+	// with the given burst. Zero values get defaults.
+	ConfirmationEmailRate  int `yaml:"CONFIRMATION_EMAIL_RATE"`
+	ConfirmationEmailBurst int `yaml:"CONFIRMATION_EMAIL_BURST"`
+
 	VehicleNFTAddr string `yaml:"VEHICLE_NFT_ADDR"`
 	ADNFTAddr      string `yaml:"AD_NFT_ADDR"`
 	TokenAddr      string `yaml:"TOKEN_ADDR"`
+	// MulticallAddr is the address of a Multicall3 deployment. If empty, balance checks
+	// are made one at a time.
+	MulticallAddr string `yaml:"MULTICALL_ADDR"`
+
+	// WalletCacheSize bounds the number of addresses in each wallet usage cache. The TTLs
+	// are in seconds; a used wallet is remembered for longer than an unused one.
+	WalletCacheSize      int `yaml:"WALLET_CACHE_SIZE"`
+	WalletCacheUsedTTL   int `yaml:"WALLET_CACHE_USED_TTL"`
+	WalletCacheUnusedTTL int `yaml:"WALLET_CACHE_UNUSED_TTL"`
+
+	// CheckEmail limits, per client IP and per email address, are average requests per
+	// minute with the given burst. Zero values get defaults.
+	CheckEmailIPRate       int `yaml:"CHECK_EMAIL_IP_RATE"`
+	CheckEmailIPBurst      int `yaml:"CHECK_EMAIL_IP_BURST"`
+	CheckEmailAddressRate  int `yaml:"CHECK_EMAIL_ADDRESS_RATE"`
+	CheckEmailAddressBurst int `yaml:"CHECK_EMAIL_ADDRESS_BURST"`
+	// TrustedProxyHops is the number of proxies in front of us that append to
+	// X-Forwarded-For. With zero, the connection's address is the client's.
+	TrustedProxyHops int `yaml:"TRUSTED_PROXY_HOPS"`
+
+	// DeletionGracePeriod is how long, in seconds, a deleted user can still be restored
+	// before being purged. Zero gets a default of 30 days.
+	DeletionGracePeriod int `yaml:"DELETION_GRACE_PERIOD"`
+
+	// KafkaBrokers is a comma-separated list of broker addresses. If empty, user events are
+	// kept in the outbox rather than published.
+	KafkaBrokers string `yaml:"KAFKA_BROKERS"`
+	EventsTopic  string `yaml:"EVENTS_TOPIC"`
 
 	MainRPCURL string `yaml:"MAIN_RPC_URL"`
+	ChainID    int64  `yaml:"CHAIN_ID"`
+	// SIWEURI is this API's public URL, named in the challenges that users sign to confirm
+	// addresses. Its host is the challenge's domain.
+	SIWEURI string `yaml:"SIWE_URI"`
+
+	// Web3IndexerStartBlock is where the web3 usage indexer begins on its first run. It
+	// should be no later than the deployment of the NFT contracts. With zero, the indexer
+	// looks for the block where the first of them was deployed.
+	Web3IndexerStartBlock int64 `yaml:"WEB3_INDEXER_START_BLOCK"`
 }
@@ -0,0 +1,490 @@
+// Package contractstest runs a simulated chain with minimal stand-ins for the contracts we
+// read, for use in tests. The stand-ins are written in EVM assembly, since we don't have the
+// real contracts' bytecode.
+package contractstest
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/DIMO-Network/users-api/internal/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/asm"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+// nftSource answers balanceOf, safeMint(address) and transferFrom like an ERC-721. Balances
+// are stored under the owner's address and the last token id under a slot no address can
+// occupy. Anyone may mint or transfer.
+const nftSource = `
+	push 0
+	calldataload
+	push 0xe0
+	shr
+	dup1
+	push 0x70a08231
+	eq
+	jumpi @balanceOf
+	dup1
+	push 0x40d097c3
+	eq
+	jumpi @safeMint
+	dup1
+	push 0x23b872dd
+	eq
+	jumpi @transferFrom
+	push 0
+	dup1
+	revert
+
+balanceOf:
+	push 4
+	calldataload
+	sload
+	push 0
+	mstore
+	push 32
+	push 0
+	return
+
+safeMint:
+	push 0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff
+	sload
+	push 1
+	add
+	dup1
+	push 0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff
+	sstore
+	push 0
+	push 4
+	calldataload
+	jump @move
+
+transferFrom:
+	push 0x44
+	calldataload
+	push 4
+	calldataload
+	push 0x24
+	calldataload
+
+;; The stack holds the token id, sender and recipient.
+move:
+	dup2
+	iszero
+	jumpi @credit
+	push 1
+	dup3
+	sload
+	sub
+	dup3
+	sstore
+
+credit:
+	dup1
+	sload
+	push 1
+	add
+	dup2
+	sstore
+	swap1
+	push 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef
+	push 0
+	push 0
+	log4
+	stop
+`
+
+// tokenSource answers balanceOf and mint(address,uint256) like an ERC-20. Anyone may mint.
+const tokenSource = `
+	push 0
+	calldataload
+	push 0xe0
+	shr
+	dup1
+	push 0x70a08231
+	eq
+	jumpi @balanceOf
+	dup1
+	push 0x40c10f19
+	eq
+	jumpi @mint
+	push 0
+	dup1
+	revert
+
+balanceOf:
+	push 4
+	calldataload
+	sload
+	push 0
+	mstore
+	push 32
+	push 0
+	return
+
+mint:
+	push 0x24
+	calldataload
+	push 4
+	calldataload
+	sload
+	add
+	push 4
+	calldataload
+	sstore
+	push 0x24
+	calldataload
+	push 0
+	mstore
+	push 4
+	calldataload
+	push 0
+	push 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef
+	push 32
+	push 0
+	log3
+	stop
+`
+
+// multicallSource answers aggregate3 like Multicall3. The calldata offsets and the next free
+// byte of output are kept in scratch memory: the calls' heads at 0x00, their count at 0x20,
+// the loop index at 0x40 and the output cursor at 0x60. The output is built from 0x100,
+// and each call's input is copied to where its result will go.
+const multicallSource = `
+	push 0
+	calldataload
+	push 0xe0
+	shr
+	push 0x82ad56cb
+	eq
+	jumpi @aggregate3
+	push 0
+	dup1
+	revert
+
+aggregate3:
+	push 4
+	calldataload
+	push 4
+	add
+	dup1
+	calldataload
+	push 0x20
+	mstore
+	push 0x20
+	add
+	push 0
+	mstore
+	push 0x20
+	push 0x100
+	mstore
+	push 0x20
+	mload
+	push 0x120
+	mstore
+	push 0x20
+	mload
+	push 5
+	shl
+	push 0x140
+	add
+	push 0x60
+	mstore
+
+loop:
+	push 0x20
+	mload
+	push 0x40
+	mload
+	lt
+	iszero
+	jumpi @done
+
+	;; Point this result's head at its tail.
+	push 0x140
+	push 0x60
+	mload
+	sub
+	push 0x40
+	mload
+	push 5
+	shl
+	push 0x140
+	add
+	mstore
+
+	;; Find the call's tuple and its calldata, then copy the calldata out.
+	push 0
+	mload
+	dup1
+	push 0x40
+	mload
+	push 5
+	shl
+	add
+	calldataload
+	add
+	dup1
+	push 0x40
+	add
+	calldataload
+	dup2
+	add
+	dup1
+	calldataload
+	dup1
+	dup3
+	push 0x20
+	add
+	push 0x60
+	mload
+	push 0x60
+	add
+	calldatacopy
+
+	push 0
+	push 0
+	dup3
+	push 0x60
+	mload
+	push 0x60
+	add
+	push 0
+	dup8
+	calldataload
+	gas
+	call
+
+	;; Fail unless the call succeeded or was allowed to fail.
+	dup1
+	dup5
+	push 0x20
+	add
+	calldataload
+	or
+	iszero
+	jumpi @fail
+
+	push 0x60
+	mload
+	mstore
+	push 0x40
+	push 0x60
+	mload
+	push 0x20
+	add
+	mstore
+	returndatasize
+	push 0x60
+	mload
+	push 0x40
+	add
+	mstore
+	returndatasize
+	push 0
+	push 0x60
+	mload
+	push 0x60
+	add
+	returndatacopy
+	push 0
+	returndatasize
+	push 0x60
+	mload
+	push 0x60
+	add
+	add
+	mstore
+
+	returndatasize
+	push 31
+	add
+	push 5
+	shr
+	push 5
+	shl
+	push 0x60
+	add
+	push 0x60
+	mload
+	add
+	push 0x60
+	mstore
+	push 0x40
+	mload
+	push 1
+	add
+	push 0x40
+	mstore
+	pop
+	pop
+	pop
+	jump @loop
+
+done:
+	push 0x100
+	push 0x60
+	mload
+	sub
+	push 0x100
+	return
+
+fail:
+	push 0
+	dup1
+	revert
+`
+
+// Chain is a simulated chain with a vehicle NFT, an aftermarket device NFT, a token and
+// Multicall3 deployed, in that order, one block each.
+type Chain struct {
+	Backend *simulated.Backend
+
+	VehicleNFT        common.Address
+	AftermarketDevice common.Address
+	Token             common.Address
+	Multicall         common.Address
+
+	t        testing.TB
+	opts     *bind.TransactOpts
+	deployed map[common.Address]uint64
+}
+
+// NewChain starts a chain and deploys the contracts. It's closed when the test finishes.
+func NewChain(t testing.TB) *Chain {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployer := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		deployer: {Balance: new(big.Int).Lsh(big.NewInt(1), 100)},
+	})
+	t.Cleanup(func() { _ = backend.Close() })
+
+	chainID, err := backend.Client().ChainID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Chain{Backend: backend, t: t, opts: opts, deployed: make(map[common.Address]uint64)}
+
+	c.VehicleNFT = c.deploy(nftSource)
+	c.AftermarketDevice = c.deploy(nftSource)
+	c.Token = c.deploy(tokenSource)
+	c.Multicall = c.deploy(multicallSource)
+
+	return c
+}
+
+// deploy assembles the runtime code in source and mines a transaction that creates it.
+func (c *Chain) deploy(source string) common.Address {
+	c.t.Helper()
+
+	comp := asm.NewCompiler(false)
+	comp.Feed(asm.Lex([]byte(source), false))
+	out, errs := comp.Compile()
+	if len(errs) != 0 {
+		c.t.Fatal(errs)
+	}
+	runtime := hexutil.MustDecode("0x" + out)
+
+	// PUSH2 len, DUP1, PUSH1 12, PUSH1 0, CODECOPY, PUSH1 0, RETURN, followed by the code.
+	initCode := append([]byte{0x61, byte(len(runtime) >> 8), byte(len(runtime)), 0x80, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x00, 0xf3}, runtime...)
+
+	addr, _, _, err := bind.DeployContract(c.opts, abi.ABI{}, initCode, c.Backend.Client())
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	c.Backend.Commit()
+	c.deployed[addr] = c.Head()
+
+	return addr
+}
+
+// DeployBlock returns the number of the block in which the contract was deployed.
+func (c *Chain) DeployBlock(contract common.Address) uint64 {
+	return c.deployed[contract]
+}
+
+// Head returns the number of the latest block.
+func (c *Chain) Head() uint64 {
+	c.t.Helper()
+
+	n, err := c.Backend.Client().BlockNumber(context.Background())
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return n
+}
+
+// mine commits the transaction and fails the test if it reverted. It returns the number of
+// the block it landed in.
+func (c *Chain) mine(tx *types.Transaction, err error) uint64 {
+	c.t.Helper()
+
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	c.Backend.Commit()
+
+	receipt, err := c.Backend.Client().TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		c.t.Fatalf("transaction %s reverted", tx.Hash())
+	}
+
+	return receipt.BlockNumber.Uint64()
+}
+
+// MintNFT mints a token of the NFT contract to the address in a new block, whose number it
+// returns.
+func (c *Chain) MintNFT(nft, to common.Address) uint64 {
+	c.t.Helper()
+
+	tr, err := contracts.NewMultiPrivilegeTransactor(nft, c.Backend.Client())
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return c.mine(tr.SafeMint(c.opts, to))
+}
+
+// TransferNFT moves the token between addresses in a new block, whose number it returns.
+func (c *Chain) TransferNFT(nft, from, to common.Address, tokenID int64) uint64 {
+	c.t.Helper()
+
+	tr, err := contracts.NewMultiPrivilegeTransactor(nft, c.Backend.Client())
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return c.mine(tr.TransferFrom(c.opts, from, to, big.NewInt(tokenID)))
+}
+
+// MintTokens mints the amount of the token to the address in a new block, whose number it
+// returns.
+func (c *Chain) MintTokens(to common.Address, amount int64) uint64 {
+	c.t.Helper()
+
+	tr, err := contracts.NewTokenTransactor(c.Token, c.Backend.Client())
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return c.mine(tr.Mint(c.opts, to, big.NewInt(amount)))
+}
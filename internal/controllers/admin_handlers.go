@@ -4,12 +4,11 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/DIMO-Network/users-api/internal/controllers/contracts"
+	"github.com/DIMO-Network/users-api/internal/services"
 	"github.com/DIMO-Network/users-api/models"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gofiber/fiber/v2"
-	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 var zero = big.NewInt(0)
@@ -23,7 +22,8 @@ func nonZero(x *big.Int) bool {
 // @Produce json
 // @Param checkEmailRequest body controllers.CheckEmailRequest true "Specify the email to check."
 // @Success 200 {object} controllers.CheckEmailResponse
-// @Failure 00 {object} controllers.ErrorResponse
+// @Failure 400 {object} controllers.ValidationErrorResponse
+// @Failure 429 {object} controllers.ErrorResponse "Rate limited. See the Retry-After header."
 // @Failure 500 {object} controllers.ErrorResponse
 // @Router /v1/check-email [post]
 func (d *UserController) CheckEmail(c *fiber.Ctx) error {
@@ -33,26 +33,27 @@ func (d *UserController) CheckEmail(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse body.")
 	}
 
+	addr, err := d.emails.Normalize(cer.Address)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ValidationErrorResponse{
+			ErrorMessage: "invalid fields in request",
+			Fields:       map[string]string{"address": "must be a valid email address"},
+		})
+	}
+
+	// This matches users_confirmed_email_address_lower_key or, when folding plus addresses,
+	// users_confirmed_email_address_folded_idx.
 	users, err := models.Users(
-		models.UserWhere.EmailAddress.EQ(null.StringFrom(cer.Address)),
+		qm.Where(d.emails.LookupSQL(models.UserColumns.EmailAddress)+" = lower(?)", addr),
 		models.UserWhere.EmailConfirmed.EQ(true),
 		models.UserWhere.EthereumConfirmed.EQ(true),
 		models.UserWhere.EthereumAddress.IsNotNull(),
+		models.UserWhere.DeletedAt.IsNull(),
 	).All(c.Context(), d.dbs.DBS().Reader)
 	if err != nil {
 		return err
 	}
 
-	// TODO(elffjs): Don't do this.
-	client, err := ethclient.Dial(d.Settings.MainRPCURL)
-	if err != nil {
-		return err
-	}
-
-	ad, _ := contracts.NewMultiPrivilege(common.HexToAddress(d.Settings.ADNFTAddr), client)
-	v, _ := contracts.NewMultiPrivilege(common.HexToAddress(d.Settings.VehicleNFTAddr), client)
-	tok, _ := contracts.NewToken(common.HexToAddress(d.Settings.TokenAddr), client)
-
 	addrsInAppStatus := make(map[common.Address]bool)
 
 	for _, user := range users {
@@ -72,37 +73,34 @@ func (d *UserController) CheckEmail(c *fiber.Ctx) error {
 		}
 	}
 
-	usedInApp, usedExternal := 0, 0
+	used := make(map[common.Address]bool, len(addrsInAppStatus))
+	var uncached []common.Address
 
-	for addr, inApp := range addrsInAppStatus {
-		used, err := func() (bool, error) {
-			if vBal, err := v.BalanceOf(nil, addr); err != nil {
-				return false, err
-			} else if nonZero(vBal) {
-				return true, nil
-			}
+	for addr := range addrsInAppStatus {
+		if u, ok := d.chainUsageCache(addrsInAppStatus[addr]).Get(addr); ok {
+			used[addr] = u
+		} else {
+			uncached = append(uncached, addr)
+		}
+	}
 
-			if adBal, err := ad.BalanceOf(nil, addr); err != nil {
-				return false, err
-			} else if nonZero(adBal) {
-				return true, nil
-			}
+	holdings, err := d.chain.Holdings(c.Context(), uncached)
+	if err != nil {
+		return fmt.Errorf("error checking chain: %w", err)
+	}
 
-			if inApp {
-				if tokBal, err := tok.BalanceOf(nil, addr); err != nil {
-					return false, err
-				} else if nonZero(tokBal) {
-					return true, nil
-				}
-			}
+	for i, addr := range uncached {
+		h := holdings[i]
+		// Only in-app wallets count as used on the strength of token holdings alone.
+		u := nonZero(h.Vehicles) || nonZero(h.AftermarketDevices) || addrsInAppStatus[addr] && nonZero(h.Tokens)
+		used[addr] = u
+		d.chainUsageCache(addrsInAppStatus[addr]).Set(addr, u)
+	}
 
-			return false, nil
-		}()
-		if err != nil {
-			return fmt.Errorf("error checking chain: %w", err)
-		}
+	usedInApp, usedExternal := 0, 0
 
-		if used {
+	for addr, inApp := range addrsInAppStatus {
+		if used[addr] {
 			if inApp {
 				usedInApp++
 			} else {
@@ -120,6 +118,15 @@ func (d *UserController) CheckEmail(c *fiber.Ctx) error {
 	})
 }
 
+// chainUsageCache returns the cache for CheckEmail's results for external or in-app
+// wallets. The same address can be either, for different users.
+func (d *UserController) chainUsageCache(inApp bool) *services.WalletUsageCache {
+	if inApp {
+		return d.inAppChainUsage
+	}
+	return d.chainUsage
+}
+
 type CheckEmailRequest struct {
 	// Address is the email address to check. Must be confirmed.
 	Address string `json:"address" example:"thaler@a16z.com"`
@@ -0,0 +1,262 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"time"
+
+	"github.com/DIMO-Network/users-api/internal/services"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type ConfirmEmailRequest struct {
+	// Key is the 6-digit number from the confirmation email
+	Key string `json:"key" example:"010990"`
+}
+
+// defaultEmailConfirmationTTL is how long, in seconds, a confirmation code is valid if
+// EMAIL_CONFIRMATION_TTL isn't set.
+const defaultEmailConfirmationTTL = 15 * 60
+
+// maxEmailConfirmationAttempts is the number of wrong codes we accept before discarding
+// the code. A new one has to be requested, and sending is rate limited.
+const maxEmailConfirmationAttempts = 5
+
+var confirmationKeyBound = big.NewInt(1_000_000)
+
+// generateConfirmationKey returns a random, zero-padded 6-digit string.
+func generateConfirmationKey() (string, error) {
+	n, err := rand.Int(rand.Reader, confirmationKeyBound)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n), nil
+}
+
+// SendConfirmationEmail godoc
+// @Summary Send a confirmation email to the authenticated user
+// @Success 204
+// @Failure 400 {object} controllers.ErrorResponse
+// @Failure 403 {object} controllers.ErrorResponse
+// @Failure 429 {object} controllers.ErrorResponse "Returned if too many emails have been sent recently."
+// @Failure 500 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user/send-confirmation-email [post]
+func (d *UserController) SendConfirmationEmail(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	user, err := services.FindUser(c.Context(), tx, userID, qm.For("UPDATE"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if !user.EmailAddress.Valid {
+		return errorResponseHandler(c, errors.New("user does not have an email address"), fiber.StatusBadRequest)
+	}
+
+	if user.EmailConfirmed {
+		return errorResponseHandler(c, errors.New("email already confirmed"), fiber.StatusBadRequest)
+	}
+
+	key, err := generateConfirmationKey()
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	user.EmailConfirmationKey = null.StringFrom(key)
+	user.EmailConfirmationSentAt = null.TimeFrom(time.Now())
+	user.EmailConfirmationAttempts = 0
+
+	if _, err := user.Update(c.Context(), tx, boil.Infer()); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	// Store the key before mailing it, and don't hold the row lock while talking to the
+	// mail server. If sending fails, the user can ask again.
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := d.sendConfirmationEmail(user.EmailAddress.String, key); err != nil {
+		d.log.Err(err).Str("userId", userID).Msg("Failed to send confirmation email.")
+		return errorResponseHandler(c, errors.New("failed to send confirmation email"), fiber.StatusInternalServerError)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// sendConfirmationEmail sends a multipart message containing the key, in both plain text
+// and rendered from the HTML template, to the given address.
+func (d *UserController) sendConfirmationEmail(to, key string) error {
+	var parts bytes.Buffer
+	w := multipart.NewWriter(&parts)
+
+	tp, err := w.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=UTF-8"}, "Content-Transfer-Encoding": {"quoted-printable"}})
+	if err != nil {
+		return err
+	}
+	tw := quotedprintable.NewWriter(tp)
+	if _, err := tw.Write([]byte("Hi,\r\n\r\nYour email verification code is: " + key + "\r\n")); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	hp, err := w.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/html; charset=UTF-8"}, "Content-Transfer-Encoding": {"quoted-printable"}})
+	if err != nil {
+		return err
+	}
+	hw := quotedprintable.NewWriter(hp)
+	if err := d.emailTemplate.Execute(hw, struct{ Key string }{Key: key}); err != nil {
+		return err
+	}
+	if err := hw.Close(); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString("From: DIMO <" + d.Settings.EmailFrom + ">\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: DIMO email confirmation\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: multipart/alternative; boundary=\"" + w.Boundary() + "\"\r\n")
+	msg.WriteString("\r\n")
+	msg.Write(parts.Bytes())
+
+	// Local stand-ins like Mailhog don't support authentication.
+	var auth smtp.Auth
+	if d.Settings.EmailUsername != "" {
+		auth = smtp.PlainAuth("", d.Settings.EmailUsername, d.Settings.EmailPassword, d.Settings.EmailHost)
+	}
+
+	addr := net.JoinHostPort(d.Settings.EmailHost, d.Settings.EmailPort)
+
+	return smtp.SendMail(addr, auth, d.Settings.EmailFrom, []string{to}, msg.Bytes())
+}
+
+// ConfirmEmail godoc
+// @Summary Submit an email confirmation key
+// @Description After five wrong keys, the key is discarded and a new email must be requested.
+// @Accept json
+// @Param confirmEmailRequest body controllers.ConfirmEmailRequest true "Specifies the key from the email"
+// @Success 204
+// @Failure 400 {object} controllers.ErrorResponse
+// @Failure 403 {object} controllers.ErrorResponse
+// @Failure 409 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user/confirm-email [post]
+func (d *UserController) ConfirmEmail(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	var cer ConfirmEmailRequest
+	if err := c.BodyParser(&cer); err != nil {
+		return errorResponseHandler(c, errors.New("couldn't parse body"), fiber.StatusBadRequest)
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	user, err := services.FindUser(c.Context(), tx, userID, qm.For("UPDATE"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if user.EmailConfirmed {
+		return errorResponseHandler(c, errors.New("email already confirmed"), fiber.StatusBadRequest)
+	}
+
+	if !user.EmailConfirmationKey.Valid || !user.EmailConfirmationSentAt.Valid {
+		return errorResponseHandler(c, errors.New("email confirmation never sent"), fiber.StatusBadRequest)
+	}
+
+	if time.Since(user.EmailConfirmationSentAt.Time) > d.emailCodeTTL {
+		return errorResponseHandler(c, errors.New("email confirmation code expired"), fiber.StatusBadRequest)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(cer.Key), []byte(user.EmailConfirmationKey.String)) != 1 {
+		msg := "email confirmation code invalid"
+
+		user.EmailConfirmationAttempts++
+		if user.EmailConfirmationAttempts >= maxEmailConfirmationAttempts {
+			msg = "too many invalid email confirmation codes, request a new one"
+			user.EmailConfirmationKey = null.StringFromPtr(nil)
+			user.EmailConfirmationSentAt = null.TimeFromPtr(nil)
+			user.EmailConfirmationAttempts = 0
+		}
+
+		if _, err := user.Update(c.Context(), tx, boil.Whitelist(
+			models.UserColumns.EmailConfirmationKey,
+			models.UserColumns.EmailConfirmationSentAt,
+			models.UserColumns.EmailConfirmationAttempts,
+		)); err != nil {
+			return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+		}
+
+		return errorResponseHandler(c, errors.New(msg), fiber.StatusBadRequest)
+	}
+
+	user.EmailConfirmed = true
+	user.EmailConfirmationKey = null.StringFromPtr(nil)
+	user.EmailConfirmationSentAt = null.TimeFromPtr(nil)
+	user.EmailConfirmationAttempts = 0
+
+	if _, err := user.Update(c.Context(), tx, boil.Infer()); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode && pqErr.Constraint == emailUniqueConstraint {
+			return errorResponseHandler(c, errors.New("email address already confirmed by another account"), fiber.StatusConflict)
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := services.EnqueueUserEvent(c.Context(), tx, services.UserEmailConfirmedEventType, services.UserEventData{
+		UserID:       userID,
+		EmailAddress: user.EmailAddress.String,
+	}); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/users-api/internal/services"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type ExportJobResponse struct {
+	// ID identifies the export in GET /v1/user/export/{exportId}.
+	ID string `json:"id" example:"2Dg3bIWAPOeUWyGJh0Z9u40fJKl"`
+	// Format is either "json" or "zip".
+	Format string `json:"format" example:"zip"`
+	// Status is one of "pending", "done", "failed" or "expired".
+	Status    string    `json:"status" example:"pending"`
+	CreatedAt time.Time `json:"createdAt" example:"2021-12-01T09:00:00Z"`
+	// CompletedAt is when the export was built, or failed to be.
+	CompletedAt null.Time `json:"completedAt" swaggertype:"string" example:"2021-12-01T09:00:04Z"`
+	// ExpiresAt is when a finished export stops being available for download.
+	ExpiresAt null.Time `json:"expiresAt" swaggertype:"string" example:"2021-12-08T09:00:04Z"`
+}
+
+func formatExportJob(e *models.UserExport) ExportJobResponse {
+	out := ExportJobResponse{
+		ID:          e.ID,
+		Format:      e.Format,
+		Status:      e.Status,
+		CreatedAt:   e.CreatedAt,
+		CompletedAt: e.CompletedAt,
+	}
+	if e.Status == services.ExportStatusDone && e.CompletedAt.Valid {
+		out.ExpiresAt = null.TimeFrom(e.CompletedAt.Time.Add(services.ExportTTL))
+	}
+	return out
+}
+
+// ExportUser godoc
+// @Summary Export all data stored about the authenticated user.
+// @Description Returns a JSON document, or a ZIP archive containing it, with the user's record,
+// @Description terms of service history, referrals, wallets and migration status. With async=true
+// @Description the export is built in the background: poll the URL in the Location header.
+// @Produce json
+// @Produce application/zip
+// @Param format query string false "Either json or zip" default(json)
+// @Param async query bool false "Build the export in the background" default(false)
+// @Success 200 {file} file
+// @Success 202 {object} controllers.ExportJobResponse
+// @Failure 400 {object} controllers.ErrorResponse
+// @Failure 404 {object} controllers.ErrorResponse
+// @Failure 500 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user/export [get]
+func (d *UserController) ExportUser(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	format := c.Query("format", services.ExportFormatJSON)
+	if format != services.ExportFormatJSON && format != services.ExportFormatZIP {
+		return errorResponseHandler(c, fmt.Errorf("format must be %q or %q", services.ExportFormatJSON, services.ExportFormatZIP), fiber.StatusBadRequest)
+	}
+
+	if c.QueryBool("async") {
+		return d.queueExport(c, userID, format)
+	}
+
+	export, err := services.BuildUserExport(c.Context(), d.dbs.DBS().Reader, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	data, err := services.EncodeUserExport(export, format)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	record := models.UserExport{
+		ID:          ksuid.New().String(),
+		UserID:      userID,
+		Format:      format,
+		Async:       false,
+		Status:      services.ExportStatusDone,
+		CompletedAt: null.TimeFrom(time.Now()),
+	}
+
+	if err := record.Insert(c.Context(), d.dbs.DBS().Writer, boil.Infer()); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	services.RecordSyncExport(services.ExportStatusDone)
+	d.log.Info().Str("userId", userID).Str("exportId", record.ID).Str("format", format).Msg("Exported user data.")
+
+	c.Attachment(services.ExportFileName(format))
+	return c.Send(data)
+}
+
+// queueExport records a pending export for the worker to build. A pending export in the
+// same format is returned instead of queueing another.
+func (d *UserController) queueExport(c *fiber.Ctx, userID, format string) error {
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := services.FindUser(c.Context(), tx, userID, qm.For("UPDATE")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	job, err := models.UserExports(
+		models.UserExportWhere.UserID.EQ(userID),
+		models.UserExportWhere.Format.EQ(format),
+		models.UserExportWhere.Status.EQ(services.ExportStatusPending),
+	).One(c.Context(), tx)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+		}
+
+		job = &models.UserExport{
+			ID:     ksuid.New().String(),
+			UserID: userID,
+			Format: format,
+			Async:  true,
+			Status: services.ExportStatusPending,
+		}
+
+		if err := job.Insert(c.Context(), tx, boil.Infer()); err != nil {
+			return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	c.Location("/v1/user/export/" + job.ID)
+	return c.Status(fiber.StatusAccepted).JSON(formatExportJob(job))
+}
+
+// GetExport godoc
+// @Summary Get an asynchronous export of the authenticated user's data.
+// @Description Returns the export once it's built, and its status until then.
+// @Produce json
+// @Produce application/zip
+// @Param exportId path string true "Export ID"
+// @Success 200 {file} file
+// @Success 202 {object} controllers.ExportJobResponse
+// @Failure 404 {object} controllers.ErrorResponse
+// @Failure 410 {object} controllers.ErrorResponse "Returned if the export has expired."
+// @Failure 500 {object} controllers.ErrorResponse "Returned if the export failed."
+// @Security BearerAuth
+// @Router /v1/user/export/{exportId} [get]
+func (d *UserController) GetExport(c *fiber.Ctx) error {
+	userID := getUserID(c)
+	exportID := c.Params("exportId")
+
+	job, err := models.UserExports(
+		models.UserExportWhere.ID.EQ(exportID),
+		models.UserExportWhere.UserID.EQ(userID),
+		models.UserExportWhere.Async.EQ(true),
+	).One(c.Context(), d.dbs.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No export with id %q.", exportID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	switch job.Status {
+	case services.ExportStatusPending:
+		return c.Status(fiber.StatusAccepted).JSON(formatExportJob(job))
+	case services.ExportStatusExpired:
+		return fiber.NewError(fiber.StatusGone, "Export has expired, request a new one.")
+	case services.ExportStatusFailed:
+		return fiber.NewError(fiber.StatusInternalServerError, "Export failed, request a new one.")
+	}
+
+	c.Attachment(services.ExportFileName(job.Format))
+	return c.Send(job.Data.Bytes)
+}
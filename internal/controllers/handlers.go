@@ -1,7 +1,9 @@
 package controllers
 
 import (
-	"github.com/ethereum/go-ethereum/common"
+	"crypto/rand"
+	"math/big"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -18,21 +20,44 @@ func errorResponseHandler(c *fiber.Ctx, err error, status int) error {
 	return c.Status(status).JSON(ErrorResponse{msg})
 }
 
+// userIDLocal is where ResolveUser leaves the user a request acts as.
+const userIDLocal = "userId"
+
+// getUserID returns the ID of the user the request acts as. Behind ResolveUser, that's
+// the user the login maps to; otherwise it's the subject.
 func getUserID(c *fiber.Ctx) string {
+	if userID, ok := c.Locals(userIDLocal).(string); ok {
+		return userID
+	}
+	return getSubject(c)
+}
+
+// getSubject returns the token's sub claim, which identifies the login.
+func getSubject(c *fiber.Ctx) string {
 	token := c.Locals("user").(*jwt.Token)
 	claims := token.Claims.(jwt.MapClaims)
 	userID := claims["sub"].(string)
 	return userID
 }
 
-func getUserEthAddr(c *fiber.Ctx) *common.Address {
+func getProviderID(c *fiber.Ctx) string {
 	token := c.Locals("user").(*jwt.Token)
 	claims := token.Claims.(jwt.MapClaims)
+	providerID, _ := claims["provider_id"].(string)
+	return providerID
+}
 
-	val, exists := claims["ethereum_address"]
-	if exists {
-		ethAddr := common.HexToAddress(val.(string))
-		return &ethAddr
+// randomString returns a string of length n with characters drawn uniformly from
+// the given alphabet using a cryptographically secure source.
+func randomString(alphabet string, n int) (string, error) {
+	bound := big.NewInt(int64(len(alphabet)))
+	out := make([]byte, n)
+	for i := range out {
+		j, err := rand.Int(rand.Reader, bound)
+		if err != nil {
+			return "", err
+		}
+		out[i] = alphabet[j.Int64()]
 	}
-	return nil
+	return string(out), nil
 }
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/DIMO-Network/users-api/internal/config"
+	"github.com/DIMO-Network/users-api/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultCheckEmailIPRate       = 30
+	defaultCheckEmailIPBurst      = 10
+	defaultCheckEmailAddressRate  = 10
+	defaultCheckEmailAddressBurst = 5
+
+	defaultConfirmationEmailRate  = 5
+	defaultConfirmationEmailBurst = 3
+)
+
+func orDefault(v, def int) int {
+	if v <= 0 {
+		return def
+	}
+	return v
+}
+
+// clientIP returns the address of the client, skipping over the given number of trusted
+// proxies. Entries further left in X-Forwarded-For are supplied by the client, and can't
+// be trusted.
+func clientIP(c *fiber.Ctx, hops int) string {
+	if hops > 0 {
+		if ips := c.IPs(); len(ips) >= hops {
+			return ips[len(ips)-hops]
+		}
+	}
+	return c.IP()
+}
+
+// allowRequest takes a token for key, setting Retry-After if there is none. If the store
+// fails, the request is let through.
+func allowRequest(c *fiber.Ctx, limiter *services.RateLimiter, key string, logger *zerolog.Logger) bool {
+	ok, retryAfter, err := limiter.Allow(c.Context(), key)
+	if err != nil {
+		logger.Err(err).Msg("Rate limit store failed, allowing request.")
+	}
+	if !ok {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(max(1, int(math.Ceil(retryAfter.Seconds())))))
+	}
+	return ok
+}
+
+// CheckEmailRateLimit limits POST /v1/check-email per client IP and per email address, so
+// that it can't cheaply be used to enumerate users. Addresses are hashed before they reach
+// the store.
+func CheckEmailRateLimit(settings *config.Settings, store services.TokenBucketStore, logger *zerolog.Logger) fiber.Handler {
+	byIP := services.NewRateLimiter("check_email_ip", store, services.PerMinute(
+		orDefault(settings.CheckEmailIPRate, defaultCheckEmailIPRate),
+		orDefault(settings.CheckEmailIPBurst, defaultCheckEmailIPBurst),
+	))
+	byAddress := services.NewRateLimiter("check_email_address", store, services.PerMinute(
+		orDefault(settings.CheckEmailAddressRate, defaultCheckEmailAddressRate),
+		orDefault(settings.CheckEmailAddressBurst, defaultCheckEmailAddressBurst),
+	))
+
+	emails := services.EmailNormalizer{FoldPlus: settings.EmailFoldPlus}
+
+	allow := func(c *fiber.Ctx, limiter *services.RateLimiter, key string) bool {
+		return allowRequest(c, limiter, key, logger)
+	}
+
+	return func(c *fiber.Ctx) error {
+		if !allow(c, byIP, "check-email:ip:"+clientIP(c, settings.TrustedProxyHops)) {
+			return errorResponseHandler(c, errors.New("too many requests"), fiber.StatusTooManyRequests)
+		}
+
+		// A bad body or address is the handler's problem.
+		var cer CheckEmailRequest
+		if err := c.BodyParser(&cer); err != nil {
+			return c.Next()
+		}
+		if addr, err := emails.Normalize(cer.Address); err == nil {
+			// Local parts are case-sensitive in principle, but not in practice.
+			sum := sha256.Sum256([]byte(strings.ToLower(addr)))
+			if !allow(c, byAddress, "check-email:address:"+hex.EncodeToString(sum[:])) {
+				return errorResponseHandler(c, errors.New("too many requests"), fiber.StatusTooManyRequests)
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// ConfirmationEmailRateLimit limits POST /v1/user/send-confirmation-email per user. Each
+// email carries a new code, with a fresh allowance of guesses, so this bounds how fast
+// codes can be guessed.
+func ConfirmationEmailRateLimit(settings *config.Settings, store services.TokenBucketStore, logger *zerolog.Logger) fiber.Handler {
+	byUser := services.NewRateLimiter("confirmation_email", store, services.PerHour(
+		orDefault(settings.ConfirmationEmailRate, defaultConfirmationEmailRate),
+		orDefault(settings.ConfirmationEmailBurst, defaultConfirmationEmailBurst),
+	))
+
+	return func(c *fiber.Ctx) error {
+		if !allowRequest(c, byUser, "confirmation-email:user:"+getUserID(c), logger) {
+			return errorResponseHandler(c, errors.New("too many confirmation emails, try again later"), fiber.StatusTooManyRequests)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/users-api/internal/services"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	referralCodeAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	referralCodeLength   = 6
+	// referralCodeAttempts bounds how many times we'll retry after colliding with an
+	// existing code. With 36^6 possible codes, needing more than one retry is rare.
+	referralCodeAttempts = 5
+
+	referralCodeUniqueConstraint = "users_referral_code_key"
+	emailUniqueConstraint        = "users_confirmed_email_address_lower_key"
+	uniqueViolationCode          = "23505"
+)
+
+type SubmitReferralCodeRequest struct {
+	// ReferralCode is the 6-digit, alphanumeric referral code from another user.
+	ReferralCode string `json:"referralCode" example:"ANB95N"`
+}
+
+type SubmitReferralCodeResponse struct {
+	Message string `json:"message"`
+}
+
+// SubmitReferralCode godoc
+// @Summary Takes the referral code, validates and stores it
+// @Accept json
+// @Param submitReferralCodeRequest body controllers.SubmitReferralCodeRequest true "ReferralCode is the 6-digit, alphanumeric referral code from another user."
+// @Success 200 {object} controllers.SubmitReferralCodeResponse
+// @Failure 400 {object} controllers.ErrorResponse
+// @Failure 500 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user/submit-referral-code [post]
+func (d *UserController) SubmitReferralCode(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	var req SubmitReferralCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorResponseHandler(c, errors.New("couldn't parse body"), fiber.StatusBadRequest)
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(req.ReferralCode))
+	if code == "" {
+		return errorResponseHandler(c, errors.New("referral code is required"), fiber.StatusBadRequest)
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	user, err := models.Users(
+		models.UserWhere.ID.EQ(userID),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.For("UPDATE"),
+	).One(c.Context(), tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if user.ReferringUserID.Valid {
+		return errorResponseHandler(c, errors.New("user has already been referred"), fiber.StatusBadRequest)
+	}
+
+	referrer, err := models.Users(
+		models.UserWhere.ReferralCode.EQ(null.StringFrom(code)),
+		models.UserWhere.DeletedAt.IsNull(),
+	).One(c.Context(), tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorResponseHandler(c, errors.New("no user with that referral code"), fiber.StatusBadRequest)
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if referrer.ID == user.ID {
+		return errorResponseHandler(c, errors.New("user cannot refer themselves"), fiber.StatusBadRequest)
+	}
+
+	// Same person, different login.
+	if user.EthereumConfirmed && referrer.EthereumConfirmed && bytes.Equal(user.EthereumAddress.Bytes, referrer.EthereumAddress.Bytes) {
+		return errorResponseHandler(c, errors.New("user cannot refer themselves"), fiber.StatusBadRequest)
+	}
+
+	if cycle, err := services.ReferralCycle(c.Context(), tx, user.ID, referrer); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	} else if cycle {
+		return errorResponseHandler(c, errors.New("referral would create a cycle"), fiber.StatusBadRequest)
+	}
+
+	user.ReferringUserID = null.StringFrom(referrer.ID)
+	user.ReferredAt = null.TimeFrom(time.Now())
+
+	if _, err := user.Update(c.Context(), tx, boil.Infer()); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	return c.JSON(SubmitReferralCodeResponse{Message: "Referral code used."})
+}
+
+// ensureReferralCode gives a user with a confirmed wallet a referral code, if they don't
+// already have one. Codes are random, so we retry on the rare collision.
+func (d *UserController) ensureReferralCode(ctx context.Context, user *models.User) error {
+	if !user.EthereumConfirmed || user.ReferralCode.Valid {
+		return nil
+	}
+
+	for i := 0; i < referralCodeAttempts; i++ {
+		code, err := randomString(referralCodeAlphabet, referralCodeLength)
+		if err != nil {
+			return err
+		}
+
+		n, err := models.Users(
+			models.UserWhere.ID.EQ(user.ID),
+			models.UserWhere.ReferralCode.IsNull(),
+		).UpdateAll(ctx, d.dbs.DBS().Writer, models.M{models.UserColumns.ReferralCode: code})
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode && pqErr.Constraint == referralCodeUniqueConstraint {
+				continue
+			}
+			return err
+		}
+
+		if n == 0 {
+			// Someone beat us to it.
+			cur, err := models.FindUser(ctx, d.dbs.DBS().Writer, user.ID, models.UserColumns.ReferralCode)
+			if err != nil {
+				return err
+			}
+			user.ReferralCode = cur.ReferralCode
+			return nil
+		}
+
+		user.ReferralCode = null.StringFrom(code)
+		return nil
+	}
+
+	return fmt.Errorf("failed to generate a unique referral code after %d attempts", referralCodeAttempts)
+}
+
+const (
+	defaultReferralsPageSize = 20
+	maxReferralsPageSize     = 100
+)
+
+type ReferralsResponse struct {
+	// Total is the number of users who have entered this user's referral code.
+	Total int64 `json:"total" example:"12"`
+	// WalletConfirmed is the number of referred users with a confirmed Ethereum address.
+	WalletConfirmed int64 `json:"walletConfirmed" example:"9"`
+	// Web3Used is the number of referred users who have used their address on-chain.
+	Web3Used int64 `json:"web3Used" example:"4"`
+	// Page is the 1-based page number of Referrals.
+	Page int `json:"page" example:"1"`
+	// PageSize is the maximum number of entries in Referrals.
+	PageSize  int             `json:"pageSize" example:"20"`
+	Referrals []ReferralEntry `json:"referrals"`
+}
+
+type ReferralEntry struct {
+	// ID is the referred user's ID with most characters masked out.
+	ID string `json:"id" example:"Chf****Rsb"`
+	// ReferredAt is when the referred user entered the referral code.
+	ReferredAt null.Time `json:"referredAt" swaggertype:"string" example:"2021-12-01T09:00:41Z"`
+	// Web3Confirmed indicates whether the referred user has confirmed an Ethereum address.
+	Web3Confirmed bool `json:"web3Confirmed" example:"true"`
+	// Web3Used indicates whether the referred user has used their address on-chain, as far
+	// as we've recorded.
+	Web3Used bool `json:"web3Used" example:"false"`
+}
+
+// GetReferrals godoc
+// @Summary List the users referred by the authenticated user.
+// @Produce json
+// @Param page query int false "1-based page number" default(1)
+// @Param pageSize query int false "Page size, at most 100" default(20)
+// @Success 200 {object} controllers.ReferralsResponse
+// @Failure 400 {object} controllers.ErrorResponse
+// @Failure 500 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user/referrals [get]
+func (d *UserController) GetReferrals(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	page := c.QueryInt("page", 1)
+	pageSize := c.QueryInt("pageSize", defaultReferralsPageSize)
+	if page < 1 || pageSize < 1 || pageSize > maxReferralsPageSize {
+		return errorResponseHandler(c, fmt.Errorf("page must be positive and pageSize must be between 1 and %d", maxReferralsPageSize), fiber.StatusBadRequest)
+	}
+
+	user, err := services.FindUser(c.Context(), d.dbs.DBS().Reader, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	total, err := user.ReferringUserUsers(
+		models.UserWhere.DeletedAt.IsNull(),
+	).Count(c.Context(), d.dbs.DBS().Reader)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	confirmed, err := user.ReferringUserUsers(
+		models.UserWhere.DeletedAt.IsNull(),
+		models.UserWhere.EthereumConfirmed.EQ(true),
+	).Count(c.Context(), d.dbs.DBS().Reader)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	used, err := user.ReferringUserUsers(
+		models.UserWhere.DeletedAt.IsNull(),
+		web3UsedRecorded(),
+	).Count(c.Context(), d.dbs.DBS().Reader)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	referees, err := user.ReferringUserUsers(
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.OrderBy(models.UserColumns.ReferredAt+" DESC, "+models.UserColumns.ID),
+		qm.Limit(pageSize),
+		qm.Offset((page-1)*pageSize),
+	).All(c.Context(), d.dbs.DBS().Reader)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	out := ReferralsResponse{
+		Total:           total,
+		WalletConfirmed: confirmed,
+		Web3Used:        used,
+		Page:            page,
+		PageSize:        pageSize,
+		Referrals:       make([]ReferralEntry, len(referees)),
+	}
+
+	for i, r := range referees {
+		out.Referrals[i] = ReferralEntry{
+			ID:            services.MaskUserID(r.ID),
+			ReferredAt:    r.ReferredAt,
+			Web3Confirmed: r.EthereumConfirmed,
+			Web3Used:      hasRecordedWeb3Use(r),
+		}
+	}
+
+	return c.JSON(out)
+}
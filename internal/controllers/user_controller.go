@@ -6,15 +6,20 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
 	"time"
 
 	pb "github.com/DIMO-Network/devices-api/pkg/grpc"
 	"github.com/DIMO-Network/shared/db"
 	"github.com/DIMO-Network/users-api/internal/config"
+	"github.com/DIMO-Network/users-api/internal/services"
 	"github.com/DIMO-Network/users-api/models"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
 	"github.com/volatiletech/null/v8"
 
 	"github.com/volatiletech/sqlboiler/v4/boil"
@@ -23,20 +28,31 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+//go:embed confirmation_email.html
+var rawConfirmationEmail string
+
 type UserController struct {
 	Settings        *config.Settings
 	dbs             db.Store
 	log             *zerolog.Logger
 	allowedLateness time.Duration
-	devicesClient   DevicesAPI
-	amClient        pb.AftermarketDeviceServiceClient
-}
-
-type DevicesAPI interface {
-	ListUserDevicesForUser(ctx context.Context, in *pb.ListUserDevicesForUserRequest, opts ...grpc.CallOption) (*pb.ListUserDevicesForUserResponse, error)
+	emailCodeTTL    time.Duration
+	siweURL         *url.URL
+	emailTemplate   *template.Template
+	emails          services.EmailNormalizer
+	devicesClient   services.DevicesAPI
+	amClient        services.AftermarketDevicesAPI
+	chain           *services.ChainClient
+	web3Indexer     *services.Web3UsageIndexer
+	// chainUsage and inAppChainUsage cache CheckEmail's on-chain results for external and
+	// in-app wallets, which are judged differently; devicesUsage caches computeWeb3Used's
+	// devices-api results.
+	chainUsage      *services.WalletUsageCache
+	inAppChainUsage *services.WalletUsageCache
+	devicesUsage    *services.WalletUsageCache
 }
 
-func NewUserController(settings *config.Settings, dbs db.Store, logger *zerolog.Logger) UserController {
+func NewUserController(settings *config.Settings, dbs db.Store, chain *services.ChainClient, web3Indexer *services.Web3UsageIndexer, logger *zerolog.Logger) UserController {
 	gc, err := grpc.NewClient(settings.DevicesAPIGRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		panic(err)
@@ -46,13 +62,37 @@ func NewUserController(settings *config.Settings, dbs db.Store, logger *zerolog.
 
 	amc := pb.NewAftermarketDeviceServiceClient(gc)
 
+	t := template.Must(template.New("confirmation_email").Parse(rawConfirmationEmail))
+
+	usedTTL := time.Duration(settings.WalletCacheUsedTTL) * time.Second
+	unusedTTL := time.Duration(settings.WalletCacheUnusedTTL) * time.Second
+
+	emailCodeTTL := time.Duration(orDefault(settings.EmailConfirmationTTL, defaultEmailConfirmationTTL)) * time.Second
+
+	siweURL, err := url.Parse(settings.SIWEURI)
+	if err != nil {
+		panic(err)
+	}
+	if siweURL.Host == "" {
+		panic("SIWE_URI must be an absolute URL")
+	}
+
 	return UserController{
 		Settings:        settings,
 		dbs:             dbs,
 		log:             logger,
 		allowedLateness: 5 * time.Minute,
+		emailCodeTTL:    emailCodeTTL,
+		siweURL:         siweURL,
+		emailTemplate:   t,
+		emails:          services.EmailNormalizer{FoldPlus: settings.EmailFoldPlus},
 		devicesClient:   dc,
 		amClient:        amc,
+		chain:           chain,
+		web3Indexer:     web3Indexer,
+		chainUsage:      services.NewWalletUsageCache("chain", settings.WalletCacheSize, usedTTL, unusedTTL),
+		inAppChainUsage: services.NewWalletUsageCache("chain_in_app", settings.WalletCacheSize, usedTTL, unusedTTL),
+		devicesUsage:    services.NewWalletUsageCache("devices", settings.WalletCacheSize, usedTTL, unusedTTL),
 	}
 }
 
@@ -97,8 +137,13 @@ type UserResponse struct {
 	CountryCode null.String `json:"countryCode" swaggertype:"string" example:"USA"`
 	// AgreedTosAt is the time at which the user last agreed to the terms of service.
 	AgreedTOSAt null.Time `json:"agreedTosAt" swaggertype:"string" example:"2021-12-01T09:00:41Z"`
-	// ReferralCode is the user's referral code to be given to others. It is an 8 alphanumeric code,
-	// only present if the account has a confirmed Ethereum address.
+	// AgreedTOSVersion is the version of the terms of service that the user last agreed to.
+	AgreedTOSVersion null.String `json:"agreedTosVersion" swaggertype:"string" example:"2024-01"`
+	// TOSOutdated is true if the user has not agreed to the current terms of service. Only
+	// computed by /v2/user.
+	TOSOutdated bool `json:"tosOutdated" example:"false"`
+	// ReferralCode is the user's referral code to be given to others. It is a 6-character alphanumeric
+	// code, only present if the account has a confirmed Ethereum address.
 	ReferralCode null.String `json:"referralCode" swaggertype:"string" example:"ANB95N"`
 	ReferredBy   null.String `json:"referredBy" swaggertype:"string" example:"0x3497B704a954789BC39999262510DE9B09Ff1366"`
 	ReferredAt   null.Time   `json:"referredAt" swaggertype:"string" example:"2021-12-01T09:00:41Z"`
@@ -128,20 +173,22 @@ func formatUser(user *models.User) *UserResponse {
 			ChallengeSentAt: user.EthereumChallengeSent,
 			InApp:           user.InAppWallet,
 		},
-		CreatedAt:    user.CreatedAt,
-		CountryCode:  user.CountryCode,
-		AgreedTOSAt:  user.AgreedTosAt,
-		ReferralCode: referralCode,
-		ReferredBy:   referrer,
-		ReferredAt:   user.ReferredAt,
-		MigratedAt:   user.MigratedAt.Ptr(),
+		CreatedAt:        user.CreatedAt,
+		CountryCode:      user.CountryCode,
+		AgreedTOSAt:      user.AgreedTosAt,
+		AgreedTOSVersion: user.AgreedTosVersion,
+		ReferralCode:     referralCode,
+		ReferredBy:       referrer,
+		ReferredAt:       user.ReferredAt,
+		MigratedAt:       user.MigratedAt.Ptr(),
 	}
 }
 
 func (d *UserController) getOrCreateUser(c *fiber.Ctx, userID string) (user *models.User, err error) {
 	user, err = models.Users(
 		models.UserWhere.ID.EQ(userID),
-		qm.Load(models.UserRels.ReferringUser),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()),
 	).One(c.Context(), d.dbs.DBS().Reader)
 	if err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
@@ -151,24 +198,31 @@ func (d *UserController) getOrCreateUser(c *fiber.Ctx, userID string) (user *mod
 		return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q. This API is deprecated and new users cannot be created.", userID))
 	}
 
-	return user, nil
+	return services.ResolveMerged(c.Context(), d.dbs.DBS().Reader, user, qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()))
 }
 
-// getUserByEth gets the users from the db with matching eth addr, but selects the one with email confirmed if more than one
-func (d *UserController) getUserByEth(ctx context.Context, ethAddr common.Address) (user *models.User, err error) {
-	user, err = models.Users(
-		models.UserWhere.EthereumAddress.EQ(null.BytesFrom(ethAddr.Bytes())),
-		qm.Load(models.UserRels.ReferringUser),
-		qm.OrderBy("email_confirmed desc"),
-	).One(ctx, d.dbs.DBS().Reader)
+// ResolveUser looks up the user the token's login acts as, for getUserID. It must run
+// after the JWT middleware. Logins without an identity act as the user with their subject
+// as ID. Tokens without a provider are rejected.
+func (d *UserController) ResolveUser(c *fiber.Ctx) error {
+	userID, err := services.ResolveIdentity(c.Context(), d.dbs.DBS().Reader, getProviderID(c), getSubject(c))
 	if err != nil {
-		return nil, err
+		if errors.Is(err, services.ErrNoProvider) {
+			return fiber.NewError(fiber.StatusUnauthorized, "Token has no provider_id claim.")
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		userID = getSubject(c)
 	}
-	return user, nil
+
+	c.Locals(userIDLocal, userID)
+
+	return c.Next()
 }
 
 // GetUserV2 godoc
-// @Summary Get attributes for the authenticated user. If multiple records for the same user, gets the one with the email confirmed.
+// @Summary Get attributes for the authenticated user. Logins linked to another user get that user's attributes.
 // @Produce json
 // @Success 200 {object} controllers.UserResponse
 // @Failure 403 {object} controllers.ErrorResponse
@@ -179,7 +233,8 @@ func (d *UserController) GetUserV2(c *fiber.Ctx) error {
 
 	user, err := models.Users(
 		models.UserWhere.ID.EQ(userID),
-		qm.Load(models.UserRels.ReferringUser),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()),
 	).One(c.Context(), d.dbs.DBS().Reader)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -188,33 +243,29 @@ func (d *UserController) GetUserV2(c *fiber.Ctx) error {
 		return err
 	}
 
-	// TODO(elffjs): The stuff below makes me incredibly nervous. Commenting it out for now. Talk to James.
-	// many users have multiple entries for the same eth_addr, but we want to use the one with verified email
-	// get users by eth addr, if it exists, order by email_confirmed desc, if userId different, use the better user but just replace the user_id
-	// ethAddr := getUserEthAddr(c)
-	// if ethAddr != nil {
-	// 	userBetter, err := d.getUserByEth(c.Context(), *ethAddr)
-	// 	if err == nil {
-	// 		if user.ID != userBetter.ID {
-	// 			// use the user with better information but preserve the original ID of the claim so not to potentially break stuff
-	// 			user = userBetter
-	// 			user.ID = userID
-	// 		}
-	// 	}
-	// }
+	user, err = services.ResolveMerged(c.Context(), d.dbs.DBS().Reader, user, qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()))
+	if err != nil {
+		return err
+	}
+
+	if err := d.ensureReferralCode(c.Context(), user); err != nil {
+		d.log.Err(err).Str("userId", userID).Msg("Failed to generate referral code.")
+	}
+
+	// A login that acts as another user sees that user's attributes under its own ID.
+	user.ID = getSubject(c)
 
 	out := formatUser(user)
 
-	out.Web3.Used, err = d.computeWeb3Used(c.Context(), user)
-	if err != nil {
-		d.log.Err(err).Str("userId", userID).Msg("Failed to determine whether user owns any NFTs.")
-	}
+	out.TOSOutdated = d.Settings.TOSVersion != "" && user.AgreedTosVersion.String != d.Settings.TOSVersion
+
+	out.Web3.Used = d.computeWeb3Used(c.Context(), user)
 
 	return c.JSON(out)
 }
 
 // GetUser godoc
-// @Summary Get attributes for the authenticated user. If multiple records for the same user, gets the one with the email confirmed.
+// @Summary Get attributes for the authenticated user. Logins linked to another user get that user's attributes.
 // @Produce json
 // @Success 200 {object} controllers.UserResponse
 // @Failure 403 {object} controllers.ErrorResponse
@@ -226,67 +277,74 @@ func (d *UserController) GetUser(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	// many users have multiple entries for the same eth_addr, but we want to use the one with verified email
-	// get users by eth addr, if it exists, order by email_confirmed desc, if userId different, use the better user but just replace the user_id
-	ethAddr := getUserEthAddr(c)
-	if ethAddr != nil {
-		userBetter, err := d.getUserByEth(c.Context(), *ethAddr)
-		if err == nil {
-			if user.ID != userBetter.ID {
-				// use the user with better information but preserve the original ID of the claim so not to potentially break stuff
-				user = userBetter
-				user.ID = userID
-			}
-		}
+
+	if err := d.ensureReferralCode(c.Context(), user); err != nil {
+		d.log.Err(err).Str("userId", userID).Msg("Failed to generate referral code.")
 	}
 
+	// A login that acts as another user sees that user's attributes under its own ID.
+	user.ID = getSubject(c)
+
 	out := formatUser(user)
 
-	out.Web3.Used, err = d.computeWeb3Used(c.Context(), user)
-	if err != nil {
-		d.log.Err(err).Str("userId", userID).Msg("Failed to determine whether user owns any NFTs.")
-	}
+	out.Web3.Used = d.computeWeb3Used(c.Context(), user)
 
 	return c.JSON(out)
 }
 
-// TODO(elffjs): Really need to get rid of this. At least hit Identity or something.
-func (d *UserController) computeWeb3Used(ctx context.Context, user *models.User) (bool, error) {
-	if user.AuthProviderID == "web3" {
-		return true, nil
+// computeWeb3Used reports whether the user signed up with a wallet or has received a
+// vehicle or aftermarket device NFT at their confirmed address. The latter is kept up to
+// date by services.Web3UsageIndexer. Until the indexer has caught up with the chain, we
+// also ask devices-api, and record a positive answer.
+func (d *UserController) computeWeb3Used(ctx context.Context, user *models.User) bool {
+	if hasRecordedWeb3Use(user) {
+		return true
 	}
 
-	if !user.EthereumConfirmed {
-		return false, nil
+	if !user.EthereumConfirmed || d.web3Indexer == nil || d.web3Indexer.CaughtUp() {
+		return false
 	}
 
-	devices, err := d.devicesClient.ListUserDevicesForUser(ctx, &pb.ListUserDevicesForUserRequest{UserId: user.ID})
-	if err != nil {
-		return false, fmt.Errorf("couldn't retrieve user's vehicles: %w", err)
-	}
+	addr := common.BytesToAddress(user.EthereumAddress.Bytes)
 
-	for _, amd := range devices.UserDevices {
-		if amd.TokenId != nil {
-			return true, nil
-		}
+	if used, ok := d.devicesUsage.Get(addr); ok {
+		return used
 	}
 
-	ams, err := d.amClient.ListAftermarketDevicesForUser(ctx, &pb.ListAftermarketDevicesForUserRequest{UserId: user.ID})
+	used, err := services.DevicesWeb3Used(ctx, d.devicesClient, d.amClient, user.ID)
 	if err != nil {
-		return false, fmt.Errorf("couldn't retrieve user's aftermarket devices: %w", err)
+		d.log.Err(err).Str("userId", user.ID).Msg("Failed to determine whether user owns any NFTs.")
+		return false
 	}
 
-	for _, am := range ams.AftermarketDevices {
-		if len(am.OwnerAddress) == 20 {
-			return true, nil
+	if used {
+		if _, err := services.StampWeb3Used(ctx, d.dbs.DBS().Writer, addr); err != nil {
+			d.log.Err(err).Str("userId", user.ID).Msg("Failed to record web3 usage.")
 		}
 	}
 
-	return false, nil
+	d.devicesUsage.Set(addr, used)
+
+	return used
+}
+
+// hasRecordedWeb3Use is computeWeb3Used without the calls to devices-api, for when there
+// are too many users to ask about.
+func hasRecordedWeb3Use(user *models.User) bool {
+	return user.AuthProviderID == "web3" || user.Web3UsedAt.Valid
+}
+
+// web3UsedRecorded matches the users for whom hasRecordedWeb3Use is true.
+func web3UsedRecorded() qm.QueryMod {
+	return qm.Expr(
+		models.UserWhere.AuthProviderID.EQ("web3"),
+		qm.Or2(models.UserWhere.Web3UsedAt.IsNotNull()),
+	)
 }
 
 // DeleteUser godoc
 // @Summary Delete the authenticated user. Fails if the user has any devices.
+// @Description The user can be restored until the deletion grace period is over.
 // @Success 204
 // @Failure 400 {object} controllers.ErrorResponse
 // @Failure 403 {object} controllers.ErrorResponse
@@ -301,7 +359,7 @@ func (d *UserController) DeleteUser(c *fiber.Ctx) error {
 	}
 	defer tx.Rollback() //nolint
 
-	user, err := models.FindUser(c.Context(), tx, userID)
+	user, err := services.FindUser(c.Context(), tx, userID, qm.For("UPDATE"))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
@@ -309,16 +367,22 @@ func (d *UserController) DeleteUser(c *fiber.Ctx) error {
 		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
 	}
 
-	dr, err := d.devicesClient.ListUserDevicesForUser(c.Context(), &pb.ListUserDevicesForUserRequest{UserId: userID})
+	n, err := services.UserDeviceCount(c.Context(), d.devicesClient, userID)
 	if err != nil {
 		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
 	}
 
-	if l := len(dr.UserDevices); l > 0 {
-		return errorResponseHandler(c, fmt.Errorf("user must delete %d devices first", l), fiber.StatusConflict)
+	if n > 0 {
+		return errorResponseHandler(c, fmt.Errorf("user must delete %d devices first", n), fiber.StatusConflict)
+	}
+
+	user.DeletedAt = null.TimeFrom(time.Now())
+
+	if _, err := user.Update(c.Context(), tx, boil.Whitelist(models.UserColumns.DeletedAt)); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
 	}
 
-	if _, err := user.Delete(c.Context(), d.dbs.DBS().Writer); err != nil {
+	if err := services.EnqueueUserEvent(c.Context(), tx, services.UserDeletedEventType, services.UserEventData{UserID: userID}); err != nil {
 		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
 	}
 
@@ -331,26 +395,274 @@ func (d *UserController) DeleteUser(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+// RestoreUser godoc
+// @Summary Undo the deletion of the authenticated user, within the grace period.
+// @Success 204
+// @Failure 404 {object} controllers.ErrorResponse "Returned if the user isn't deleted."
+// @Failure 410 {object} controllers.ErrorResponse "Returned if the grace period is over."
+// @Security BearerAuth
+// @Router /v1/user/restore [post]
+func (d *UserController) RestoreUser(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	user, err := models.Users(
+		models.UserWhere.ID.EQ(userID),
+		models.UserWhere.DeletedAt.IsNotNull(),
+		qm.For("UPDATE"),
+	).One(c.Context(), tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No deleted user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	// The purger may not have got to it yet.
+	if time.Since(user.DeletedAt.Time) > services.DeletionGracePeriod(d.Settings) {
+		return errorResponseHandler(c, errors.New("deletion grace period is over"), fiber.StatusGone)
+	}
+
+	user.DeletedAt = null.TimeFromPtr(nil)
+
+	if _, err := user.Update(c.Context(), tx, boil.Whitelist(models.UserColumns.DeletedAt)); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := services.EnqueueUserEvent(c.Context(), tx, services.UserRestoredEventType, services.UserEventData{UserID: userID}); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	d.log.Info().Str("userId", userID).Msg("Restored user.")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
 // SetMigrated godoc
 // @Summary Sets the migration timestamp.
 // @Success 204
 // @Failure 400 {object} controllers.ErrorResponse
+// @Failure 404 {object} controllers.ErrorResponse
 // @Router /v1/user/set-migrated [post]
 func (d *UserController) SetMigrated(c *fiber.Ctx) error {
 	userID := getUserID(c)
 
-	user, err := d.getOrCreateUser(c, userID)
+	found, err := d.getOrCreateUser(c, userID)
 	if err != nil {
 		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
 	}
 
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	// Re-read under lock, so that we don't write back columns changed since.
+	user, err := services.FindUser(c.Context(), tx, found.ID, qm.For("UPDATE"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", found.ID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
 	if d.Settings.Environment == "dev" && c.Query("clear") == "true" {
 		user.MigratedAt = null.TimeFromPtr(nil)
 	} else {
 		user.MigratedAt = null.TimeFrom(time.Now())
 	}
 
-	if _, err := user.Update(c.Context(), d.dbs.DBS().Writer, boil.Infer()); err != nil {
+	if _, err := user.Update(c.Context(), tx, boil.Whitelist(models.UserColumns.MigratedAt)); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if user.MigratedAt.Valid {
+		if err := services.EnqueueUserEvent(c.Context(), tx, services.UserMigratedEventType, services.UserEventData{
+			UserID:     userID,
+			MigratedAt: user.MigratedAt.Ptr(),
+		}); err != nil {
+			return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+type UserUpdateRequest struct {
+	// Email, if present, specifies changes to the user's email.
+	Email *UserUpdateRequestEmail `json:"email"`
+	// CountryCode, if specified, should be a valid ISO 3166-1 alpha-3 country code. An empty
+	// string clears the field.
+	CountryCode *string `json:"countryCode" example:"USA"`
+}
+
+type UserUpdateRequestEmail struct {
+	// Address, if present, should be a valid email address. Note when this field
+	// is modified the user's verification status will reset.
+	Address *string `json:"address" example:"neal@dimo.zone"`
+}
+
+// ValidationErrorResponse is returned when one or more fields of a request are invalid.
+type ValidationErrorResponse struct {
+	ErrorMessage string `json:"errorMessage"`
+	// Fields maps JSON paths of the offending fields to a description of the problem.
+	Fields map[string]string `json:"fields" example:"countryCode:must be a valid ISO 3166-1 alpha-3 country code"`
+}
+
+// UpdateUser godoc
+// @Summary Modify attributes for the authenticated user
+// @Accept json
+// @Produce json
+// @Param userUpdateRequest body controllers.UserUpdateRequest true "New field values"
+// @Success 200 {object} controllers.UserResponse
+// @Failure 400 {object} controllers.ValidationErrorResponse
+// @Failure 403 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user [put]
+func (d *UserController) UpdateUser(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	var req UserUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorResponseHandler(c, errors.New("couldn't parse body"), fiber.StatusBadRequest)
+	}
+
+	fields := make(map[string]string)
+
+	var countryCode null.String
+	if req.CountryCode != nil && *req.CountryCode != "" {
+		code := strings.ToUpper(strings.TrimSpace(*req.CountryCode))
+		if services.ValidCountryCode(code) {
+			countryCode = null.StringFrom(code)
+		} else {
+			fields["countryCode"] = "must be a valid ISO 3166-1 alpha-3 country code"
+		}
+	}
+
+	var emailAddress string
+	if req.Email != nil && req.Email.Address != nil {
+		var err error
+		if emailAddress, err = d.emails.Normalize(*req.Email.Address); err != nil {
+			fields["email.address"] = "must be a valid email address"
+		}
+	}
+
+	if len(fields) != 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(ValidationErrorResponse{
+			ErrorMessage: "invalid fields in request",
+			Fields:       fields,
+		})
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	user, err := models.Users(
+		models.UserWhere.ID.EQ(userID),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()),
+		qm.For("UPDATE"),
+	).One(c.Context(), tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if req.CountryCode != nil {
+		user.CountryCode = countryCode
+	}
+
+	if req.Email != nil && req.Email.Address != nil && emailAddress != user.EmailAddress.String {
+		user.EmailAddress = null.StringFrom(emailAddress)
+		user.EmailConfirmed = false
+		user.EmailConfirmationKey = null.StringFromPtr(nil)
+		user.EmailConfirmationSentAt = null.TimeFromPtr(nil)
+	}
+
+	if _, err := user.Update(c.Context(), tx, boil.Infer()); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	out := formatUser(user)
+
+	out.Web3.Used = d.computeWeb3Used(c.Context(), user)
+
+	return c.JSON(out)
+}
+
+// AgreeTOS godoc
+// @Summary Agree to the current terms of service
+// @Success 204
+// @Failure 400 {object} controllers.ErrorResponse
+// @Failure 503 {object} controllers.ErrorResponse "Returned if no terms of service version is configured."
+// @Security BearerAuth
+// @Router /v1/user/agree-tos [post]
+func (d *UserController) AgreeTOS(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	// Without a version there's nothing to record agreement to.
+	if d.Settings.TOSVersion == "" {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "No terms of service version is configured.")
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	user, err := services.FindUser(c.Context(), tx, userID, qm.For("UPDATE"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	now := time.Now()
+
+	user.AgreedTosAt = null.TimeFrom(now)
+	user.AgreedTosVersion = null.StringFrom(d.Settings.TOSVersion)
+
+	if _, err := user.Update(c.Context(), tx, boil.Whitelist(models.UserColumns.AgreedTosAt, models.UserColumns.AgreedTosVersion)); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	agreement := models.TosAgreement{
+		ID:       ksuid.New().String(),
+		UserID:   userID,
+		Version:  d.Settings.TOSVersion,
+		AgreedAt: now,
+	}
+
+	if err := agreement.Insert(c.Context(), tx, boil.Infer()); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := tx.Commit(); err != nil {
 		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
 	}
 
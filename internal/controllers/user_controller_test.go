@@ -1,28 +1,43 @@
 package controllers
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"math/big"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	pb "github.com/DIMO-Network/devices-api/pkg/grpc"
 	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/users-api/internal/config"
+	"github.com/DIMO-Network/users-api/internal/contracts/contractstest"
 	"github.com/DIMO-Network/users-api/internal/database"
+	"github.com/DIMO-Network/users-api/internal/services"
 	"github.com/DIMO-Network/users-api/models"
 	"github.com/docker/go-connections/nat"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"google.golang.org/grpc"
 )
 
@@ -95,6 +110,22 @@ func (s *UserControllerTestSuite) TearDownSuite() {
 func (s *UserControllerTestSuite) TearDownTest() {
 	_, err := models.Users().DeleteAll(context.Background(), s.dbs.DBS().Writer)
 	s.Require().NoError(err)
+	_, err = models.Outboxes().DeleteAll(context.Background(), s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+	_, err = models.UserExports().DeleteAll(context.Background(), s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+}
+
+// outboxEvents returns the events waiting in the outbox, oldest first.
+func (s *UserControllerTestSuite) outboxEvents() []services.UserEvent {
+	rows, err := models.Outboxes(qm.OrderBy(models.OutboxColumns.ID)).All(context.Background(), s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+
+	out := make([]services.UserEvent, len(rows))
+	for i, r := range rows {
+		s.Require().NoError(json.Unmarshal(r.Payload, &out[i]))
+	}
+	return out
 }
 
 type udsc struct {
@@ -105,10 +136,12 @@ func (c *udsc) ListUserDevicesForUser(_ context.Context, in *pb.ListUserDevicesF
 	return &pb.ListUserDevicesForUserResponse{UserDevices: c.store[in.UserId]}, nil
 }
 
-type adsc struct{}
+type amsc struct {
+	store map[string][]*pb.AftermarketDevice
+}
 
-func (c *adsc) ListAftermarketDevicesForUser(_ context.Context, _ *pb.ListAftermarketDevicesForUserRequest, _ ...grpc.CallOption) (*pb.ListAftermarketDevicesForUserResponse, error) {
-	return &pb.ListAftermarketDevicesForUserResponse{AftermarketDevices: []*pb.AftermarketDevice{}}, nil
+func (c *amsc) ListAftermarketDevicesForUser(_ context.Context, in *pb.ListAftermarketDevicesForUserRequest, _ ...grpc.CallOption) (*pb.ListAftermarketDevicesForUserResponse, error) {
+	return &pb.ListAftermarketDevicesForUserResponse{AftermarketDevices: c.store[in.UserId]}, nil
 }
 
 func (s *UserControllerTestSuite) TestGetUser_OnlyUserID() {
@@ -119,7 +152,6 @@ func (s *UserControllerTestSuite) TestGetUser_OnlyUserID() {
 		log:             s.logger,
 		allowedLateness: 5 * time.Minute,
 		devicesClient:   &udsc{},
-		amClient:        &adsc{},
 	}
 
 	app := fiber.New()
@@ -179,7 +211,7 @@ func (s *UserControllerTestSuite) TestGetUser_OnlyUserID() {
 	s.Require().Equal(eResp.ReferredBy, null.StringFrom(common.BytesToAddress(nu.EthereumAddress.Bytes).Hex()))
 }
 
-func (s *UserControllerTestSuite) TestGetUser_EthAddr() {
+func (s *UserControllerTestSuite) TestGetUser_Identity() {
 	ctx := context.Background()
 
 	uc := UserController{
@@ -187,7 +219,6 @@ func (s *UserControllerTestSuite) TestGetUser_EthAddr() {
 		log:             s.logger,
 		allowedLateness: 5 * time.Minute,
 		devicesClient:   &udsc{},
-		amClient:        &adsc{},
 	}
 
 	pk, err := crypto.GenerateKey()
@@ -199,13 +230,12 @@ func (s *UserControllerTestSuite) TestGetUser_EthAddr() {
 
 	app.Use(func(c *fiber.Ctx) error {
 		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
-			"provider_id":      "apple",
-			"sub":              "SomeID",
-			"ethereum_address": addr.Hex(),
+			"provider_id": "apple",
+			"sub":         "SomeID",
 		}})
 		return c.Next()
 	})
-	// both users with same eth addr
+	// Two logins, one linked to the other.
 	nu := models.User{
 		ID:                "SomeID",
 		EmailConfirmed:    false, // we don't want this user to be returned
@@ -230,7 +260,10 @@ func (s *UserControllerTestSuite) TestGetUser_EthAddr() {
 	err = nu2.Insert(ctx, uc.dbs.DBS().Writer, boil.Infer())
 	s.Require().NoError(err)
 
-	app.Get("/", uc.GetUser)
+	identity := models.UserIdentity{ProviderID: "apple", Subject: nu.ID, UserID: nu2.ID}
+	s.Require().NoError(identity.Insert(ctx, uc.dbs.DBS().Writer, boil.Infer()))
+
+	app.Get("/", uc.ResolveUser, uc.GetUser)
 
 	r := httptest.NewRequest("GET", "/", nil)
 	resp, err := app.Test(r, -1)
@@ -243,6 +276,1537 @@ func (s *UserControllerTestSuite) TestGetUser_EthAddr() {
 	s.Require().NoError(err)
 
 	s.Assert().Equal(200, resp.StatusCode)
-	s.Require().Equal(eResp.ID, nu.ID)                                     // use the original ID
-	s.Require().Equal(eResp.Email.Address.String, nu2.EmailAddress.String) // but the confirmed account email address
+	s.Require().Equal(eResp.ID, nu.ID)                                     // use the login's ID
+	s.Require().Equal(eResp.Email.Address.String, nu2.EmailAddress.String) // but the linked user's email address
+
+	// Without a provider the login can't be resolved.
+	noProvider := fiber.New()
+	noProvider.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "SomeID",
+		}})
+		return c.Next()
+	})
+	noProvider.Get("/", uc.ResolveUser, uc.GetUser)
+
+	resp2, err := noProvider.Test(httptest.NewRequest("GET", "/", nil), -1)
+	s.Require().NoError(err)
+	defer resp2.Body.Close()
+	s.Equal(fiber.StatusUnauthorized, resp2.StatusCode)
+}
+
+func (s *UserControllerTestSuite) TestGetUser_Web3UsedFallback() {
+	ctx := context.Background()
+
+	tokenID := uint64(7)
+
+	uc := UserController{
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		devicesClient: &udsc{store: map[string][]*pb.UserDevice{
+			"Minted": {{Id: "2OQjmqUt9dguQbJt1WImuVfje3W", TokenId: &tokenID}},
+		}},
+		amClient: &amsc{},
+		// Not caught up, since it has never run.
+		web3Indexer:  &services.Web3UsageIndexer{},
+		devicesUsage: services.NewWalletUsageCache("devices", 10, time.Hour, time.Hour),
+	}
+
+	app := fiber.New()
+
+	var subject string
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": subject,
+		}})
+		return c.Next()
+	})
+
+	app.Get("/", uc.GetUser)
+
+	for i, id := range []string{"Minted", "Unminted"} {
+		u := models.User{
+			ID:                id,
+			CreatedAt:         time.Now(),
+			ReferralCode:      null.StringFrom(fmt.Sprintf("ABCDE%d", i)),
+			EthereumAddress:   null.BytesFrom(common.BigToAddress(big.NewInt(int64(i + 1))).Bytes()),
+			EthereumConfirmed: true,
+		}
+		s.Require().NoError(u.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+	}
+
+	for _, tc := range []struct {
+		ID   string
+		Used bool
+	}{
+		{ID: "Minted", Used: true},
+		{ID: "Unminted", Used: false},
+	} {
+		subject = tc.ID
+
+		r := httptest.NewRequest("GET", "/", nil)
+		resp, err := app.Test(r, -1)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+
+		s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+
+		var ur UserResponse
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&ur))
+		s.Equal(tc.Used, ur.Web3.Used, tc.ID)
+
+		// A positive answer from devices-api is kept.
+		u, err := models.FindUser(ctx, s.dbs.DBS().Reader, tc.ID)
+		s.Require().NoError(err)
+		s.Equal(tc.Used, u.Web3UsedAt.Valid, tc.ID)
+	}
+}
+
+func (s *UserControllerTestSuite) TestSendConfirmationEmail() {
+	ctx := context.Background()
+
+	mhReq := testcontainers.ContainerRequest{
+		Image:        "mailhog/mailhog:v1.0.1",
+		ExposedPorts: []string{"1025/tcp", "8025/tcp"},
+		AutoRemove:   true,
+		WaitingFor:   wait.ForListeningPort("1025/tcp"),
+	}
+	mhcont, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: mhReq,
+		Started:          true,
+	})
+	s.Require().NoError(err)
+	defer mhcont.Terminate(ctx) //nolint
+
+	host, err := mhcont.Host(ctx)
+	s.Require().NoError(err)
+
+	smtpPort, err := mhcont.MappedPort(ctx, "1025/tcp")
+	s.Require().NoError(err)
+
+	apiPort, err := mhcont.MappedPort(ctx, "8025/tcp")
+	s.Require().NoError(err)
+
+	uc := NewUserController(&config.Settings{
+		EmailHost: host,
+		EmailPort: smtpPort.Port(),
+		EmailFrom: "mailer@dimo.zone",
+		SIWEURI:   "https://users-api.dimo.zone",
+	}, s.dbs, nil, nil, s.logger)
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Cwbs",
+		}})
+		return c.Next()
+	})
+
+	nu := models.User{
+		ID:             "Cwbs",
+		EmailAddress:   null.StringFrom("steve@apple.com"),
+		EmailConfirmed: false,
+		CreatedAt:      time.Now(),
+	}
+
+	err = nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	app.Post("/", uc.SendConfirmationEmail)
+
+	r := httptest.NewRequest("POST", "/", nil)
+	resp, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusNoContent, resp.StatusCode)
+
+	err = nu.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+
+	s.Require().True(nu.EmailConfirmationKey.Valid)
+	s.Require().Len(nu.EmailConfirmationKey.String, 6)
+	s.Require().True(nu.EmailConfirmationSentAt.Valid)
+
+	mhResp, err := http.Get(fmt.Sprintf("http://%s:%s/api/v2/messages", host, apiPort.Port()))
+	s.Require().NoError(err)
+	defer mhResp.Body.Close()
+
+	var messages struct {
+		Total int `json:"total"`
+		Items []struct {
+			Raw struct {
+				To   []string `json:"To"`
+				Data string   `json:"Data"`
+			} `json:"Raw"`
+		} `json:"items"`
+	}
+	err = json.NewDecoder(mhResp.Body).Decode(&messages)
+	s.Require().NoError(err)
+
+	s.Require().Equal(1, messages.Total)
+	s.Require().Equal([]string{"steve@apple.com"}, messages.Items[0].Raw.To)
+	s.Require().Contains(messages.Items[0].Raw.Data, nu.EmailConfirmationKey.String)
+}
+
+func (s *UserControllerTestSuite) TestConfirmEmail() {
+	ctx := context.Background()
+
+	uc := UserController{
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		emailCodeTTL:    15 * time.Minute,
+		devicesClient:   &udsc{},
+	}
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Cwbs",
+		}})
+		return c.Next()
+	})
+
+	nu := models.User{
+		ID:                      "Cwbs",
+		EmailAddress:            null.StringFrom("steve@apple.com"),
+		EmailConfirmed:          false,
+		EmailConfirmationKey:    null.StringFrom("010990"),
+		EmailConfirmationSentAt: null.TimeFrom(time.Now().Add(-time.Minute)),
+		CreatedAt:               time.Now(),
+	}
+
+	err := nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	app.Post("/", uc.ConfirmEmail)
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"key": "123456"}`))
+	r.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusBadRequest, resp.StatusCode)
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"key": "010990"}`))
+	r.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(r, -1)
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusNoContent, resp.StatusCode)
+
+	err = nu.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+
+	s.Require().True(nu.EmailConfirmed)
+	s.Require().False(nu.EmailConfirmationKey.Valid)
+
+	events := s.outboxEvents()
+	s.Require().Len(events, 1)
+	s.Equal(services.UserEmailConfirmedEventType, events[0].Type)
+	s.Equal("Cwbs", events[0].Subject)
+	s.Equal("steve@apple.com", events[0].Data.EmailAddress)
+}
+
+func (s *UserControllerTestSuite) TestConfirmEmail_Attempts() {
+	ctx := context.Background()
+
+	uc := UserController{
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		emailCodeTTL:    15 * time.Minute,
+		devicesClient:   &udsc{},
+	}
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Cwbs",
+		}})
+		return c.Next()
+	})
+
+	app.Post("/", uc.ConfirmEmail)
+
+	nu := models.User{
+		ID:                      "Cwbs",
+		EmailAddress:            null.StringFrom("steve@apple.com"),
+		EmailConfirmationKey:    null.StringFrom("010990"),
+		EmailConfirmationSentAt: null.TimeFrom(time.Now().Add(-time.Minute)),
+		CreatedAt:               time.Now(),
+	}
+	s.Require().NoError(nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	confirm := func(key string) int {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(fmt.Sprintf(`{"key": %q}`, key)))
+		r.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(r, -1)
+		s.Require().NoError(err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	for i := 1; i < maxEmailConfirmationAttempts; i++ {
+		s.Equal(fiber.StatusBadRequest, confirm(fmt.Sprintf("%06d", i)))
+	}
+
+	s.Require().NoError(nu.Reload(ctx, s.dbs.DBS().Reader))
+	s.Equal(maxEmailConfirmationAttempts-1, nu.EmailConfirmationAttempts)
+	s.True(nu.EmailConfirmationKey.Valid)
+
+	// The last wrong guess throws the code away, so the right one no longer works.
+	s.Equal(fiber.StatusBadRequest, confirm("999999"))
+	s.Equal(fiber.StatusBadRequest, confirm("010990"))
+
+	s.Require().NoError(nu.Reload(ctx, s.dbs.DBS().Reader))
+	s.False(nu.EmailConfirmed)
+	s.False(nu.EmailConfirmationKey.Valid)
+	s.Zero(nu.EmailConfirmationAttempts)
+}
+
+func (s *UserControllerTestSuite) TestConfirmationEmailRateLimit() {
+	settings := &config.Settings{
+		ConfirmationEmailRate:  1,
+		ConfirmationEmailBurst: 2,
+	}
+
+	app := fiber.New()
+
+	var subject string
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": subject,
+		}})
+		return c.Next()
+	})
+
+	app.Post("/", ConfirmationEmailRateLimit(settings, services.NewInMemoryTokenBucketStore(), s.logger), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	send := func(userID string) *http.Response {
+		subject = userID
+		resp, err := app.Test(httptest.NewRequest("POST", "/", nil), -1)
+		s.Require().NoError(err)
+		resp.Body.Close()
+		return resp
+	}
+
+	s.Equal(fiber.StatusNoContent, send("Cwbs").StatusCode)
+	s.Equal(fiber.StatusNoContent, send("Cwbs").StatusCode)
+
+	resp := send("Cwbs")
+	s.Equal(fiber.StatusTooManyRequests, resp.StatusCode)
+	s.NotEmpty(resp.Header.Get(fiber.HeaderRetryAfter))
+
+	s.Equal(fiber.StatusNoContent, send("Other").StatusCode)
+}
+
+func (s *UserControllerTestSuite) TestConfirmEmail_Taken() {
+	ctx := context.Background()
+
+	uc := UserController{
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		emailCodeTTL:    15 * time.Minute,
+	}
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Cwbs",
+		}})
+		return c.Next()
+	})
+
+	other := models.User{
+		ID:             "Other",
+		EmailAddress:   null.StringFrom("Steve@apple.com"),
+		EmailConfirmed: true,
+		CreatedAt:      time.Now(),
+	}
+	s.Require().NoError(other.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	nu := models.User{
+		ID:                      "Cwbs",
+		EmailAddress:            null.StringFrom("steve@apple.com"),
+		EmailConfirmationKey:    null.StringFrom("010990"),
+		EmailConfirmationSentAt: null.TimeFrom(time.Now().Add(-time.Minute)),
+		CreatedAt:               time.Now(),
+	}
+	s.Require().NoError(nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	app.Post("/", uc.ConfirmEmail)
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"key": "010990"}`))
+	r.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusConflict, resp.StatusCode)
+
+	s.Require().NoError(nu.Reload(ctx, s.dbs.DBS().Reader))
+	s.False(nu.EmailConfirmed)
+	s.Empty(s.outboxEvents())
+}
+
+func (s *UserControllerTestSuite) TestWeb3Challenge() {
+	ctx := context.Background()
+
+	chain := contractstest.NewChain(s.T())
+
+	settings := &config.Settings{
+		ChainID:        137,
+		VehicleNFTAddr: chain.VehicleNFT.Hex(),
+		ADNFTAddr:      chain.AftermarketDevice.Hex(),
+		TokenAddr:      chain.Token.Hex(),
+	}
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Cwbs",
+		}})
+		return c.Next()
+	})
+
+	pk, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	addr := crypto.PubkeyToAddress(pk.PublicKey)
+
+	// The address already owns a vehicle.
+	chain.MintNFT(chain.VehicleNFT, addr)
+
+	chainClient, err := services.NewChainClient(chain.Backend.Client(), settings)
+	s.Require().NoError(err)
+
+	uc := UserController{
+		Settings:        settings,
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		devicesClient:   &udsc{},
+		chain:           chainClient,
+		siweURL:         &url.URL{Scheme: "https", Host: "users-api.dimo.zone"},
+	}
+
+	nu := models.User{
+		ID:        "Cwbs",
+		CreatedAt: time.Now(),
+	}
+
+	err = nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	app.Post("/generate", uc.GenerateEthereumChallenge)
+	app.Post("/submit", uc.SubmitEthereumChallenge)
+
+	r := httptest.NewRequest("POST", "/generate", strings.NewReader(fmt.Sprintf(`{"address": %q}`, addr.Hex())))
+	r.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+
+	var cr ChallengeResponse
+	err = json.NewDecoder(resp.Body).Decode(&cr)
+	s.Require().NoError(err)
+
+	s.Require().Contains(cr.Challenge, addr.Hex())
+	s.Require().Contains(cr.Challenge, "Chain ID: 137")
+	// The request's host doesn't matter.
+	s.Require().True(strings.HasPrefix(cr.Challenge, "users-api.dimo.zone wants you to sign in"))
+	s.Require().Contains(cr.Challenge, "URI: https://users-api.dimo.zone\n")
+
+	sig, err := crypto.Sign(accounts.TextHash([]byte(cr.Challenge)), pk)
+	s.Require().NoError(err)
+	sig[crypto.RecoveryIDOffset] += 27
+
+	r = httptest.NewRequest("POST", "/submit", strings.NewReader(fmt.Sprintf(`{"signature": %q}`, hexutil.Encode(sig))))
+	r.Header.Set("Content-Type", "application/json")
+	resp2, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp2.Body.Close()
+
+	s.Require().Equal(fiber.StatusNoContent, resp2.StatusCode)
+
+	err = nu.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+
+	s.Require().True(nu.EthereumConfirmed)
+	s.Require().Equal(addr.Bytes(), nu.EthereumAddress.Bytes)
+	s.Require().False(nu.EthereumChallenge.Valid)
+	s.True(nu.Web3UsedAt.Valid)
+
+	wallet, err := models.FindUserWallet(ctx, s.dbs.DBS().Reader, nu.ID, addr.Bytes())
+	s.Require().NoError(err)
+	s.True(wallet.IsPrimary)
+	s.True(wallet.ConfirmedAt.Valid)
+	s.Equal(services.WalletKindExternal, wallet.Kind)
+
+	events := s.outboxEvents()
+	s.Require().Len(events, 1)
+	s.Equal(services.UserWeb3ConfirmedEventType, events[0].Type)
+	s.Equal(addr.Hex(), events[0].Data.EthereumAddress)
+}
+
+func (s *UserControllerTestSuite) TestSubmitReferralCode() {
+	ctx := context.Background()
+
+	uc := UserController{
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		devicesClient:   &udsc{},
+	}
+
+	sub := "Referee"
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": sub,
+		}})
+		return c.Next()
+	})
+
+	app.Post("/", uc.SubmitReferralCode)
+
+	pk1, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	pk2, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	referrer := models.User{
+		ID:                "Referrer",
+		CreatedAt:         time.Now(),
+		ReferralCode:      null.StringFrom("ANB95N"),
+		EthereumAddress:   null.BytesFrom(crypto.PubkeyToAddress(pk1.PublicKey).Bytes()),
+		EthereumConfirmed: true,
+	}
+
+	referee := models.User{
+		ID:                "Referee",
+		CreatedAt:         time.Now(),
+		ReferralCode:      null.StringFrom("QQ77PL"),
+		EthereumAddress:   null.BytesFrom(crypto.PubkeyToAddress(pk2.PublicKey).Bytes()),
+		EthereumConfirmed: true,
+	}
+
+	s.Require().NoError(referrer.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+	s.Require().NoError(referee.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"referralCode": "anb95n"}`))
+	r.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+
+	s.Require().NoError(referee.Reload(ctx, s.dbs.DBS().Reader))
+	s.Require().Equal(null.StringFrom(referrer.ID), referee.ReferringUserID)
+	s.Require().True(referee.ReferredAt.Valid)
+
+	// Now the referrer tries to close the loop.
+	sub = "Referrer"
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"referralCode": "QQ77PL"}`))
+	r.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(r, -1)
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusBadRequest, resp.StatusCode)
+
+	s.Require().NoError(referrer.Reload(ctx, s.dbs.DBS().Reader))
+	s.Require().False(referrer.ReferringUserID.Valid)
+}
+
+func (s *UserControllerTestSuite) TestGetReferrals() {
+	ctx := context.Background()
+
+	uc := UserController{
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		devicesClient:   &udsc{},
+	}
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Referrer",
+		}})
+		return c.Next()
+	})
+
+	app.Get("/", uc.GetReferrals)
+
+	referrer := models.User{
+		ID:        "Referrer",
+		CreatedAt: time.Now(),
+	}
+	s.Require().NoError(referrer.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	for i, confirmed := range []bool{true, false, true} {
+		nu := models.User{
+			ID:                fmt.Sprintf("ChFrb2JsaXR6QGRpbW8uem9uZRIGZ29vZ2xl%d", i),
+			CreatedAt:         time.Now(),
+			EthereumConfirmed: confirmed,
+			ReferringUserID:   null.StringFrom(referrer.ID),
+			ReferredAt:        null.TimeFrom(time.Now().Add(time.Duration(i) * time.Minute)),
+		}
+		if i == 2 {
+			nu.Web3UsedAt = null.TimeFrom(time.Now())
+		}
+		s.Require().NoError(nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+	}
+
+	r := httptest.NewRequest("GET", "/?pageSize=2", nil)
+	resp, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+
+	var rr ReferralsResponse
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&rr))
+
+	s.Equal(int64(3), rr.Total)
+	s.Equal(int64(2), rr.WalletConfirmed)
+	s.Equal(int64(1), rr.Web3Used)
+	s.Require().Len(rr.Referrals, 2)
+	s.Equal("ChF****xl2", rr.Referrals[0].ID)
+	s.True(rr.Referrals[0].Web3Confirmed)
+	s.True(rr.Referrals[0].Web3Used)
+	s.False(rr.Referrals[1].Web3Used)
+}
+
+func (s *UserControllerTestSuite) TestExportUser() {
+	ctx := context.Background()
+
+	uc := UserController{
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		devicesClient:   &udsc{},
+	}
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Referee",
+		}})
+		return c.Next()
+	})
+
+	app.Get("/export", uc.ExportUser)
+	app.Get("/export/:exportId", uc.GetExport)
+
+	referrer := models.User{
+		ID:        "CiQ4ZmE0ZGRiMC1hZWI5LTQ1ZGQtYjQ2Mi1mMDkzOTNkZmMxMjASBmdvb2dsZQ",
+		CreatedAt: time.Now(),
+	}
+	s.Require().NoError(referrer.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	user := models.User{
+		ID:              "Referee",
+		CreatedAt:       time.Now(),
+		EmailAddress:    null.StringFrom("referee@dimo.zone"),
+		ReferringUserID: null.StringFrom(referrer.ID),
+		ReferredAt:      null.TimeFrom(time.Now()),
+		MigratedAt:      null.TimeFrom(time.Now()),
+	}
+	s.Require().NoError(user.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	referee := models.User{
+		ID:              "ChFrb2JsaXR6QGRpbW8uem9uZRIGZ29vZ2xl",
+		CreatedAt:       time.Now(),
+		ReferringUserID: null.StringFrom(user.ID),
+		ReferredAt:      null.TimeFrom(time.Now()),
+	}
+	s.Require().NoError(referee.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	deletedReferee := models.User{
+		ID:              "DeletedReferee",
+		CreatedAt:       time.Now(),
+		ReferringUserID: null.StringFrom(user.ID),
+		ReferredAt:      null.TimeFrom(time.Now()),
+		DeletedAt:       null.TimeFrom(time.Now()),
+	}
+	s.Require().NoError(deletedReferee.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	tos := models.TosAgreement{
+		ID:       ksuid.New().String(),
+		UserID:   user.ID,
+		Version:  "2023-06-01",
+		AgreedAt: time.Now(),
+	}
+	s.Require().NoError(tos.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	r := httptest.NewRequest("GET", "/export", nil)
+	resp, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+	s.Contains(resp.Header.Get("Content-Disposition"), "dimo-user-data.json")
+
+	var export services.UserDataExport
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&export))
+
+	s.Equal(user.ID, export.User.ID)
+	s.Equal("referee@dimo.zone", export.User.EmailAddress.String)
+	s.Require().NotNil(export.Referrer)
+	// Neither side of the referral sees the other's ID.
+	s.Equal("CiQ****sZQ", export.Referrer.ID)
+	s.Require().Len(export.Referees, 1)
+	s.Equal("ChF****2xl", export.Referees[0].ID)
+	s.Require().Len(export.TOSAgreements, 1)
+	s.Equal("2023-06-01", export.TOSAgreements[0].Version)
+	s.True(export.Migration.Migrated)
+
+	r = httptest.NewRequest("GET", "/export?format=zip", nil)
+	resp, err = app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+	s.Contains(resp.Header.Get("Content-Disposition"), "dimo-user-data.zip")
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	s.Require().NoError(err)
+	s.Require().Len(zr.File, 1)
+	s.Equal("dimo-user-data.json", zr.File[0].Name)
+
+	r = httptest.NewRequest("GET", "/export?format=xml", nil)
+	resp, err = app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(fiber.StatusBadRequest, resp.StatusCode)
+
+	// Asynchronous exports are queued once per format until the worker gets to them.
+	var job ExportJobResponse
+	for i := 0; i < 2; i++ {
+		r = httptest.NewRequest("GET", "/export?format=zip&async=true", nil)
+		resp, err = app.Test(r, -1)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+
+		s.Require().Equal(fiber.StatusAccepted, resp.StatusCode)
+
+		var jr ExportJobResponse
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&jr))
+		s.Equal(services.ExportStatusPending, jr.Status)
+		s.Equal("/v1/user/export/"+jr.ID, resp.Header.Get("Location"))
+		if i > 0 {
+			s.Equal(job.ID, jr.ID)
+		}
+		job = jr
+	}
+
+	r = httptest.NewRequest("GET", "/export/"+job.ID, nil)
+	resp, err = app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(fiber.StatusAccepted, resp.StatusCode)
+
+	worker := services.NewExportWorker(s.dbs, s.logger)
+
+	ok, err := worker.ProcessNext(ctx)
+	s.Require().NoError(err)
+	s.True(ok)
+
+	ok, err = worker.ProcessNext(ctx)
+	s.Require().NoError(err)
+	s.False(ok)
+
+	r = httptest.NewRequest("GET", "/export/"+job.ID, nil)
+	resp, err = app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+	s.Contains(resp.Header.Get("Content-Disposition"), "dimo-user-data.zip")
+
+	r = httptest.NewRequest("GET", "/export/nope", nil)
+	resp, err = app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(fiber.StatusNotFound, resp.StatusCode)
+
+	// Each export is recorded.
+	records, err := models.UserExports(
+		models.UserExportWhere.UserID.EQ(user.ID),
+	).All(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Len(records, 3)
+
+	for _, rec := range records {
+		s.Equal(services.ExportStatusDone, rec.Status)
+		if !rec.Async {
+			s.False(rec.Data.Valid)
+		}
+	}
+
+	// Old results are dropped, but the record remains.
+	_, err = models.UserExports(
+		models.UserExportWhere.ID.EQ(job.ID),
+	).UpdateAll(ctx, s.dbs.DBS().Writer, models.M{
+		models.UserExportColumns.CompletedAt: time.Now().Add(-services.ExportTTL - time.Hour),
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(worker.Expire(ctx))
+
+	r = httptest.NewRequest("GET", "/export/"+job.ID, nil)
+	resp, err = app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(fiber.StatusGone, resp.StatusCode)
+
+	// A deleted referrer isn't mentioned.
+	referrer.DeletedAt = null.TimeFrom(time.Now())
+	_, err = referrer.Update(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	built, err := services.BuildUserExport(ctx, s.dbs.DBS().Reader, user.ID)
+	s.Require().NoError(err)
+	s.Nil(built.Referrer)
+}
+
+func (s *UserControllerTestSuite) TestUpdateUser() {
+	ctx := context.Background()
+
+	uc := UserController{
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		devicesClient:   &udsc{},
+	}
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Cwbs",
+		}})
+		return c.Next()
+	})
+
+	app.Put("/", uc.UpdateUser)
+
+	nu := models.User{
+		ID:             "Cwbs",
+		EmailAddress:   null.StringFrom("steve@apple.com"),
+		EmailConfirmed: true,
+		CountryCode:    null.StringFrom("USA"),
+		CreatedAt:      time.Now(),
+	}
+	s.Require().NoError(nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	r := httptest.NewRequest("PUT", "/", strings.NewReader(`{"countryCode": "XYZ", "email": {"address": "not an email"}}`))
+	r.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusBadRequest, resp.StatusCode)
+
+	var ver ValidationErrorResponse
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&ver))
+	s.Contains(ver.Fields, "countryCode")
+	s.Contains(ver.Fields, "email.address")
+
+	r = httptest.NewRequest("PUT", "/", strings.NewReader(`{"countryCode": "can", "email": {"address": "tim@apple.com"}}`))
+	r.Header.Set("Content-Type", "application/json")
+	resp2, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp2.Body.Close()
+
+	s.Require().Equal(fiber.StatusOK, resp2.StatusCode)
+
+	s.Require().NoError(nu.Reload(ctx, s.dbs.DBS().Reader))
+	s.Equal(null.StringFrom("CAN"), nu.CountryCode)
+	s.Equal(null.StringFrom("tim@apple.com"), nu.EmailAddress)
+	s.False(nu.EmailConfirmed)
+
+	// Addresses are stored normalized.
+	uc.emails.FoldPlus = true
+
+	r = httptest.NewRequest("PUT", "/", strings.NewReader(`{"email": {"address": " Jony+dimo@Apple.COM "}}`))
+	r.Header.Set("Content-Type", "application/json")
+	resp3, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp3.Body.Close()
+
+	s.Require().Equal(fiber.StatusOK, resp3.StatusCode)
+
+	s.Require().NoError(nu.Reload(ctx, s.dbs.DBS().Reader))
+	s.Equal(null.StringFrom("Jony@apple.com"), nu.EmailAddress)
+}
+
+func (s *UserControllerTestSuite) TestAgreeTOS() {
+	ctx := context.Background()
+
+	uc := UserController{
+		Settings:        &config.Settings{TOSVersion: "2024-01"},
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		devicesClient:   &udsc{},
+	}
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Cwbs",
+		}})
+		return c.Next()
+	})
+
+	app.Get("/v2/user", uc.GetUserV2)
+	app.Post("/agree-tos", uc.AgreeTOS)
+
+	nu := models.User{
+		ID:               "Cwbs",
+		AgreedTosAt:      null.TimeFrom(time.Now().Add(-24 * time.Hour)),
+		AgreedTosVersion: null.StringFrom("2023-06"),
+		CreatedAt:        time.Now(),
+	}
+	s.Require().NoError(nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	getUser := func() UserResponse {
+		resp, err := app.Test(httptest.NewRequest("GET", "/v2/user", nil), -1)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+
+		var ur UserResponse
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&ur))
+		return ur
+	}
+
+	s.True(getUser().TOSOutdated)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/agree-tos", nil), -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(fiber.StatusNoContent, resp.StatusCode)
+
+	ur := getUser()
+	s.False(ur.TOSOutdated)
+	s.Equal(null.StringFrom("2024-01"), ur.AgreedTOSVersion)
+
+	agreements, err := models.TosAgreements(models.TosAgreementWhere.UserID.EQ("Cwbs")).All(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Require().Len(agreements, 1)
+	s.Equal("2024-01", agreements[0].Version)
+
+	// Nothing is recorded without a configured version.
+	uc.Settings.TOSVersion = ""
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/agree-tos", nil), -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Equal(fiber.StatusServiceUnavailable, resp.StatusCode)
+
+	agreements, err = models.TosAgreements(models.TosAgreementWhere.UserID.EQ("Cwbs")).All(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Len(agreements, 1)
+}
+
+func (s *UserControllerTestSuite) TestSetMigrated() {
+	ctx := context.Background()
+
+	uc := UserController{
+		Settings:        &config.Settings{Environment: "dev"},
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		devicesClient:   &udsc{},
+	}
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Cwbs",
+		}})
+		return c.Next()
+	})
+
+	app.Post("/set-migrated", uc.SetMigrated)
+
+	nu := models.User{
+		ID:          "Cwbs",
+		CountryCode: null.StringFrom("USA"),
+		CreatedAt:   time.Now(),
+	}
+	s.Require().NoError(nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/set-migrated", nil), -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(fiber.StatusNoContent, resp.StatusCode)
+
+	s.Require().NoError(nu.Reload(ctx, s.dbs.DBS().Reader))
+	s.True(nu.MigratedAt.Valid)
+	s.Equal(null.StringFrom("USA"), nu.CountryCode)
+
+	events := s.outboxEvents()
+	s.Require().Len(events, 1)
+	s.Equal(services.UserMigratedEventType, events[0].Type)
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/set-migrated?clear=true", nil), -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(fiber.StatusNoContent, resp.StatusCode)
+
+	s.Require().NoError(nu.Reload(ctx, s.dbs.DBS().Reader))
+	s.False(nu.MigratedAt.Valid)
+	s.Len(s.outboxEvents(), 1)
+}
+
+func (s *UserControllerTestSuite) TestDeleteUser() {
+	ctx := context.Background()
+
+	devices := &udsc{store: map[string][]*pb.UserDevice{
+		"Cwbs": {{Id: "2OQjmqUt9dguQbJt1WImuVfje3W"}},
+	}}
+
+	uc := UserController{
+		Settings:        &config.Settings{},
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		devicesClient:   devices,
+	}
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Cwbs",
+		}})
+		return c.Next()
+	})
+
+	app.Get("/", uc.GetUser)
+	app.Delete("/", uc.DeleteUser)
+	app.Post("/restore", uc.RestoreUser)
+
+	nu := models.User{
+		ID:        "Cwbs",
+		CreatedAt: time.Now(),
+	}
+	s.Require().NoError(nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/", nil), -1)
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusConflict, resp.StatusCode)
+	s.Empty(s.outboxEvents())
+
+	delete(devices.store, "Cwbs")
+
+	resp, err = app.Test(httptest.NewRequest("DELETE", "/", nil), -1)
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusNoContent, resp.StatusCode)
+
+	// The row stays until the grace period is over.
+	s.Require().NoError(nu.Reload(ctx, s.dbs.DBS().Reader))
+	s.True(nu.DeletedAt.Valid)
+
+	events := s.outboxEvents()
+	s.Require().Len(events, 1)
+	s.Equal(services.UserDeletedEventType, events[0].Type)
+	s.Equal("Cwbs", events[0].Subject)
+
+	for _, r := range []*http.Request{httptest.NewRequest("GET", "/", nil), httptest.NewRequest("DELETE", "/", nil)} {
+		resp, err = app.Test(r, -1)
+		s.Require().NoError(err)
+		resp.Body.Close()
+		s.Equal(fiber.StatusNotFound, resp.StatusCode)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/restore", nil), -1)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(fiber.StatusNoContent, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/", nil), -1)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Equal(fiber.StatusOK, resp.StatusCode)
+
+	events = s.outboxEvents()
+	s.Require().Len(events, 2)
+	s.Equal(services.UserRestoredEventType, events[1].Type)
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/restore", nil), -1)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Equal(fiber.StatusNotFound, resp.StatusCode)
+
+	// Too late to restore, even if the purger hasn't run.
+	nu.DeletedAt = null.TimeFrom(time.Now().Add(-31 * 24 * time.Hour))
+	_, err = nu.Update(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/restore", nil), -1)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Equal(fiber.StatusGone, resp.StatusCode)
+}
+
+// countingBackend counts the contract calls made through a chain backend.
+type countingBackend struct {
+	services.ChainBackend
+	calls int
+}
+
+func (b *countingBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	b.calls++
+	return b.ChainBackend.CallContract(ctx, call, blockNumber)
+}
+
+func (s *UserControllerTestSuite) TestCheckEmail() {
+	s.Run("Sequential", func() { s.checkEmail(false) })
+	s.Run("Multicall", func() { s.checkEmail(true) })
+}
+
+func (s *UserControllerTestSuite) checkEmail(multicall bool) {
+	ctx := context.Background()
+
+	chain := contractstest.NewChain(s.T())
+
+	settings := &config.Settings{
+		VehicleNFTAddr: chain.VehicleNFT.Hex(),
+		ADNFTAddr:      chain.AftermarketDevice.Hex(),
+		TokenAddr:      chain.Token.Hex(),
+	}
+	if multicall {
+		settings.MulticallAddr = chain.Multicall.Hex()
+	}
+
+	vehicleOwner := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	tokenHolder := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	unused := common.HexToAddress("0x0000000000000000000000000000000000000003")
+
+	chain.MintNFT(chain.VehicleNFT, vehicleOwner)
+	chain.MintTokens(tokenHolder, 100)
+	chain.MintTokens(unused, 100)
+
+	backend := &countingBackend{ChainBackend: chain.Backend.Client()}
+
+	chainClient, err := services.NewChainClient(backend, settings)
+	s.Require().NoError(err)
+
+	uc := UserController{
+		Settings:        settings,
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		devicesClient:   &udsc{},
+		chain:           chainClient,
+		chainUsage:      services.NewWalletUsageCache("test", 10, time.Hour, time.Hour),
+		inAppChainUsage: services.NewWalletUsageCache("test_in_app", 10, time.Hour, time.Hour),
+	}
+
+	app := fiber.New()
+	app.Post("/", uc.CheckEmail)
+
+	wallets := []struct {
+		email string
+		addr  common.Address
+		inApp bool
+		want  CheckEmailResponse
+	}{
+		{"steve@apple.com", vehicleOwner, false, CheckEmailResponse{InUse: true, Wallets: CheckWallets{External: 1}}},
+		{"tim@apple.com", tokenHolder, true, CheckEmailResponse{InUse: true, Wallets: CheckWallets{InApp: 1}}},
+		// Tokens alone don't count for external wallets.
+		{"jony@apple.com", unused, false, CheckEmailResponse{}},
+	}
+
+	for i, u := range wallets {
+		nu := models.User{
+			ID:                fmt.Sprintf("User%d", i),
+			EmailAddress:      null.StringFrom(u.email),
+			EmailConfirmed:    true,
+			EthereumAddress:   null.BytesFrom(u.addr.Bytes()),
+			EthereumConfirmed: true,
+			InAppWallet:       u.inApp,
+			CreatedAt:         time.Now(),
+		}
+		s.Require().NoError(nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+	}
+	defer s.TearDownTest()
+
+	check := func() {
+		for _, u := range wallets {
+			// Lookups ignore case.
+			r := httptest.NewRequest("POST", "/", strings.NewReader(fmt.Sprintf(`{"address": %q}`, strings.ToUpper(u.email))))
+			r.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(r, -1)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+
+			var cer CheckEmailResponse
+			s.Require().NoError(json.NewDecoder(resp.Body).Decode(&cer))
+
+			s.Equal(u.want, cer, u.email)
+		}
+	}
+
+	check()
+
+	if multicall {
+		s.Equal(3, backend.calls)
+	} else {
+		s.Equal(9, backend.calls)
+	}
+
+	// Every wallet is now cached.
+	calls := backend.calls
+	check()
+	s.Equal(calls, backend.calls)
+
+	// The cached result for an external wallet doesn't answer for an in-app one.
+	_, err = models.Users(models.UserWhere.EmailAddress.EQ(null.StringFrom("jony@apple.com"))).UpdateAll(ctx, s.dbs.DBS().Writer, models.M{models.UserColumns.InAppWallet: true})
+	s.Require().NoError(err)
+	wallets[2].want = CheckEmailResponse{InUse: true, Wallets: CheckWallets{InApp: 1}}
+
+	check()
+	s.Greater(backend.calls, calls)
+}
+
+func (s *UserControllerTestSuite) TestCheckEmail_FoldPlus() {
+	ctx := context.Background()
+
+	chain := contractstest.NewChain(s.T())
+
+	settings := &config.Settings{
+		VehicleNFTAddr: chain.VehicleNFT.Hex(),
+		ADNFTAddr:      chain.AftermarketDevice.Hex(),
+		TokenAddr:      chain.Token.Hex(),
+		EmailFoldPlus:  true,
+	}
+
+	owner := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	chain.MintNFT(chain.VehicleNFT, owner)
+
+	chainClient, err := services.NewChainClient(chain.Backend.Client(), settings)
+	s.Require().NoError(err)
+
+	uc := UserController{
+		Settings:        settings,
+		dbs:             s.dbs,
+		log:             s.logger,
+		emails:          services.EmailNormalizer{FoldPlus: true},
+		chain:           chainClient,
+		chainUsage:      services.NewWalletUsageCache("test_fold", 10, time.Hour, time.Hour),
+		inAppChainUsage: services.NewWalletUsageCache("test_fold_in_app", 10, time.Hour, time.Hour),
+	}
+
+	app := fiber.New()
+	app.Post("/", uc.CheckEmail)
+
+	// Stored before folding was turned on.
+	nu := models.User{
+		ID:                "User",
+		EmailAddress:      null.StringFrom("Steve+dimo@apple.com"),
+		EmailConfirmed:    true,
+		EthereumAddress:   null.BytesFrom(owner.Bytes()),
+		EthereumConfirmed: true,
+		CreatedAt:         time.Now(),
+	}
+	s.Require().NoError(nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+	defer s.TearDownTest()
+
+	for _, addr := range []string{"steve@apple.com", "steve+other@apple.com"} {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(fmt.Sprintf(`{"address": %q}`, addr)))
+		r.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(r, -1)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+
+		s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+
+		var cer CheckEmailResponse
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&cer))
+		s.Equal(CheckEmailResponse{InUse: true, Wallets: CheckWallets{External: 1}}, cer, addr)
+	}
+}
+
+func (s *UserControllerTestSuite) TestCheckEmail_InvalidAddress() {
+	uc := UserController{
+		dbs: s.dbs,
+		log: s.logger,
+	}
+
+	app := fiber.New()
+	app.Post("/", uc.CheckEmail)
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"address": "Steve <steve@apple.com>"}`))
+	r.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(r, -1)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(fiber.StatusBadRequest, resp.StatusCode)
+
+	var ver ValidationErrorResponse
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&ver))
+	s.Contains(ver.Fields, "address")
+}
+
+func (s *UserControllerTestSuite) TestCheckEmailRateLimit() {
+	settings := &config.Settings{
+		CheckEmailIPRate:       1,
+		CheckEmailIPBurst:      3,
+		CheckEmailAddressRate:  1,
+		CheckEmailAddressBurst: 2,
+		TrustedProxyHops:       1,
+	}
+
+	app := fiber.New()
+	app.Post("/", CheckEmailRateLimit(settings, services.NewInMemoryTokenBucketStore(), s.logger), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	check := func(ip, address string) *http.Response {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(fmt.Sprintf(`{"address": %q}`, address)))
+		r.Header.Set("Content-Type", "application/json")
+		// The client can put whatever it likes in front of the proxy's entry.
+		r.Header.Set("X-Forwarded-For", "10.0.0.1, "+ip)
+		resp, err := app.Test(r, -1)
+		s.Require().NoError(err)
+		resp.Body.Close()
+		return resp
+	}
+
+	s.Equal(fiber.StatusOK, check("1.1.1.1", "steve@apple.com").StatusCode)
+	// Case and whitespace don't make a new address.
+	s.Equal(fiber.StatusOK, check("2.2.2.2", " Steve@apple.com").StatusCode)
+
+	resp := check("3.3.3.3", "steve@apple.com")
+	s.Equal(fiber.StatusTooManyRequests, resp.StatusCode)
+	s.Equal("60", resp.Header.Get(fiber.HeaderRetryAfter))
+
+	s.Equal(fiber.StatusOK, check("1.1.1.1", "tim@apple.com").StatusCode)
+	s.Equal(fiber.StatusOK, check("1.1.1.1", "jony@apple.com").StatusCode)
+
+	resp = check("1.1.1.1", "phil@apple.com")
+	s.Equal(fiber.StatusTooManyRequests, resp.StatusCode)
+	s.NotEmpty(resp.Header.Get(fiber.HeaderRetryAfter))
+}
+
+func (s *UserControllerTestSuite) TestClientIP() {
+	// As deployed: Cloudflare appends the client's address, and the ingress appends
+	// Cloudflare's.
+	const hops = 2
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(clientIP(c, hops))
+	})
+
+	for _, tc := range []struct {
+		Name          string
+		XForwardedFor string
+		Expected      string
+	}{
+		{Name: "through both proxies", XForwardedFor: "1.1.1.1, 172.68.0.1", Expected: "1.1.1.1"},
+		{Name: "spoofed entries", XForwardedFor: "6.6.6.6, 7.7.7.7, 1.1.1.1, 172.68.0.1", Expected: "1.1.1.1"},
+		{Name: "too few entries", XForwardedFor: "172.68.0.1", Expected: "0.0.0.0"},
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("X-Forwarded-For", tc.XForwardedFor)
+		resp, err := app.Test(r, -1)
+		s.Require().NoError(err)
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		s.Require().NoError(err)
+
+		s.Equal(tc.Expected, string(body), tc.Name)
+	}
+}
+
+func (s *UserControllerTestSuite) TestWallets() {
+	ctx := context.Background()
+
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "Cwbs",
+		}})
+		return c.Next()
+	})
+
+	chain := contractstest.NewChain(s.T())
+
+	settings := &config.Settings{
+		ChainID:        137,
+		VehicleNFTAddr: chain.VehicleNFT.Hex(),
+		ADNFTAddr:      chain.AftermarketDevice.Hex(),
+		TokenAddr:      chain.Token.Hex(),
+	}
+
+	primary := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	pk, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	second := crypto.PubkeyToAddress(pk.PublicKey)
+
+	// The second wallet already owns an aftermarket device.
+	chain.MintNFT(chain.AftermarketDevice, second)
+
+	chainClient, err := services.NewChainClient(chain.Backend.Client(), settings)
+	s.Require().NoError(err)
+
+	uc := UserController{
+		Settings:        settings,
+		dbs:             s.dbs,
+		log:             s.logger,
+		allowedLateness: 5 * time.Minute,
+		devicesClient:   &udsc{},
+		chain:           chainClient,
+		siweURL:         &url.URL{Scheme: "https", Host: "users-api.dimo.zone"},
+	}
+
+	nu := models.User{
+		ID:                "Cwbs",
+		CreatedAt:         time.Now(),
+		EthereumAddress:   null.BytesFrom(primary.Bytes()),
+		EthereumConfirmed: true,
+		ReferralCode:      null.StringFrom("ABCDEF"),
+	}
+	s.Require().NoError(nu.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	_, err = services.ConfirmWallet(ctx, s.dbs.DBS().Writer, nu.ID, primary, services.WalletKindExternal)
+	s.Require().NoError(err)
+
+	app.Get("/wallets", uc.ListWallets)
+	app.Post("/wallets", uc.AddWallet)
+	app.Delete("/wallets/:address", uc.RemoveWallet)
+	app.Post("/wallets/:address/primary", uc.SetPrimaryWallet)
+	app.Post("/generate", uc.GenerateEthereumChallenge)
+	app.Post("/submit", uc.SubmitEthereumChallenge)
+
+	do := func(method, path, body string) *http.Response {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(r, -1)
+		s.Require().NoError(err)
+		s.T().Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := do("POST", "/wallets", `{"address": "not an address", "kind": "paper"}`)
+	s.Equal(fiber.StatusBadRequest, resp.StatusCode)
+
+	resp = do("POST", "/wallets", fmt.Sprintf(`{"address": %q, "kind": "in_app", "label": "Phone"}`, second.Hex()))
+	s.Require().Equal(fiber.StatusCreated, resp.StatusCode)
+
+	resp = do("POST", "/wallets", fmt.Sprintf(`{"address": %q}`, second.Hex()))
+	s.Equal(fiber.StatusConflict, resp.StatusCode)
+
+	// Unconfirmed wallets can't be primary.
+	resp = do("POST", "/wallets/"+second.Hex()+"/primary", "")
+	s.Equal(fiber.StatusConflict, resp.StatusCode)
+
+	resp = do("POST", "/generate", fmt.Sprintf(`{"address": %q}`, primary.Hex()))
+	s.Equal(fiber.StatusBadRequest, resp.StatusCode)
+
+	resp = do("POST", "/generate", fmt.Sprintf(`{"address": %q}`, second.Hex()))
+	s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+
+	var cr ChallengeResponse
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&cr))
+
+	sig, err := crypto.Sign(accounts.TextHash([]byte(cr.Challenge)), pk)
+	s.Require().NoError(err)
+
+	resp = do("POST", "/submit", fmt.Sprintf(`{"signature": %q}`, hexutil.Encode(sig)))
+	s.Require().Equal(fiber.StatusNoContent, resp.StatusCode)
+
+	// Confirming another wallet leaves the primary one alone.
+	s.Require().NoError(nu.Reload(ctx, s.dbs.DBS().Reader))
+	s.Equal(primary.Bytes(), nu.EthereumAddress.Bytes)
+	s.True(nu.Web3UsedAt.Valid)
+
+	events := s.outboxEvents()
+	s.Require().Len(events, 1)
+	s.Equal(services.UserWeb3ConfirmedEventType, events[0].Type)
+	s.Equal(second.Hex(), events[0].Data.EthereumAddress)
+
+	resp = do("GET", "/wallets", "")
+	s.Require().Equal(fiber.StatusOK, resp.StatusCode)
+
+	var wallets []WalletResponse
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&wallets))
+	s.Require().Len(wallets, 2)
+	s.Equal(primary.Hex(), wallets[0].Address)
+	s.True(wallets[0].Primary)
+	s.Equal(second.Hex(), wallets[1].Address)
+	s.Equal(services.WalletKindInApp, wallets[1].Kind)
+	s.Equal("Phone", wallets[1].Label.String)
+	s.True(wallets[1].ConfirmedAt.Valid)
+
+	resp = do("POST", "/wallets/"+second.Hex()+"/primary", "")
+	s.Require().Equal(fiber.StatusNoContent, resp.StatusCode)
+
+	s.Require().NoError(nu.Reload(ctx, s.dbs.DBS().Reader))
+	s.Equal(second.Bytes(), nu.EthereumAddress.Bytes)
+	s.True(nu.EthereumConfirmed)
+	s.True(nu.InAppWallet)
+
+	events = s.outboxEvents()
+	s.Require().Len(events, 2)
+	s.Equal(services.UserWeb3ConfirmedEventType, events[1].Type)
+	s.Equal(second.Hex(), events[1].Data.EthereumAddress)
+
+	resp = do("DELETE", "/wallets/"+second.Hex(), "")
+	s.Equal(fiber.StatusConflict, resp.StatusCode)
+
+	resp = do("DELETE", "/wallets/"+primary.Hex(), "")
+	s.Equal(fiber.StatusNoContent, resp.StatusCode)
+
+	resp = do("DELETE", "/wallets/"+primary.Hex(), "")
+	s.Equal(fiber.StatusNotFound, resp.StatusCode)
 }
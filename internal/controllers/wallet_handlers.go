@@ -0,0 +1,310 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/users-api/internal/services"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// maxWalletLabelLength caps the length of a wallet label, in bytes.
+const maxWalletLabelLength = 64
+
+type WalletResponse struct {
+	// Address is the wallet's Ethereum address.
+	Address string `json:"address" example:"0x142e0C7A098622Ea98E5D67034251C4dFA746B5d"`
+	// Kind is either "external" or "in_app", for wallets managed by the DIMO app.
+	Kind string `json:"kind" example:"external"`
+	// Label is a name the user has given the wallet.
+	Label null.String `json:"label" swaggertype:"string" example:"Hardware wallet"`
+	// ConfirmedAt is when the user proved ownership of the wallet by signing a challenge.
+	// Unconfirmed wallets are not used to look the user up.
+	ConfirmedAt null.Time `json:"confirmedAt" swaggertype:"string" example:"2021-12-01T09:01:12Z"`
+	// Primary is true for the wallet reported as the user's address in /v1/user.
+	Primary   bool      `json:"primary" example:"true"`
+	CreatedAt time.Time `json:"createdAt" example:"2021-12-01T09:00:00Z"`
+}
+
+type AddWalletRequest struct {
+	// Address is the wallet's Ethereum address. Confirm it with the web3 challenge endpoints.
+	Address string `json:"address" example:"0x142e0C7A098622Ea98E5D67034251C4dFA746B5d"`
+	// Kind is either "external", the default, or "in_app".
+	Kind string `json:"kind" example:"external"`
+	// Label is an optional name for the wallet.
+	Label null.String `json:"label" swaggertype:"string" example:"Hardware wallet"`
+}
+
+func formatWallet(w *models.UserWallet) WalletResponse {
+	return WalletResponse{
+		Address:     common.BytesToAddress(w.Address).Hex(),
+		Kind:        w.Kind,
+		Label:       w.Label,
+		ConfirmedAt: w.ConfirmedAt,
+		Primary:     w.IsPrimary,
+		CreatedAt:   w.CreatedAt,
+	}
+}
+
+// walletParam parses the address path parameter.
+func walletParam(c *fiber.Ctx) (common.Address, error) {
+	raw := c.Params("address")
+	if !common.IsHexAddress(raw) {
+		return common.Address{}, fmt.Errorf("invalid ethereum address %q", raw)
+	}
+	return common.HexToAddress(raw), nil
+}
+
+// ListWallets godoc
+// @Summary List the authenticated user's wallets, primary first.
+// @Produce json
+// @Success 200 {array} controllers.WalletResponse
+// @Failure 404 {object} controllers.ErrorResponse
+// @Failure 500 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user/wallets [get]
+func (d *UserController) ListWallets(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	if _, err := services.FindUser(c.Context(), d.dbs.DBS().Reader, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	wallets, err := models.UserWallets(
+		models.UserWalletWhere.UserID.EQ(userID),
+		qm.OrderBy(models.UserWalletColumns.IsPrimary+" DESC, "+models.UserWalletColumns.CreatedAt),
+	).All(c.Context(), d.dbs.DBS().Reader)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	out := make([]WalletResponse, len(wallets))
+	for i, w := range wallets {
+		out[i] = formatWallet(w)
+	}
+
+	return c.JSON(out)
+}
+
+// AddWallet godoc
+// @Summary Add an unconfirmed wallet to the authenticated user.
+// @Description Confirm the wallet by generating and submitting a web3 challenge for its address.
+// @Accept json
+// @Produce json
+// @Param addWalletRequest body controllers.AddWalletRequest true "Wallet to add"
+// @Success 201 {object} controllers.WalletResponse
+// @Failure 400 {object} controllers.ValidationErrorResponse
+// @Failure 404 {object} controllers.ErrorResponse
+// @Failure 409 {object} controllers.ErrorResponse "Returned if the user already has the wallet."
+// @Failure 500 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user/wallets [post]
+func (d *UserController) AddWallet(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	var awr AddWalletRequest
+	if err := c.BodyParser(&awr); err != nil {
+		return errorResponseHandler(c, errors.New("couldn't parse body"), fiber.StatusBadRequest)
+	}
+
+	if awr.Kind == "" {
+		awr.Kind = services.WalletKindExternal
+	}
+
+	fields := make(map[string]string)
+	if !common.IsHexAddress(awr.Address) {
+		fields["address"] = "must be an Ethereum address"
+	}
+	if awr.Kind != services.WalletKindExternal && awr.Kind != services.WalletKindInApp {
+		fields["kind"] = fmt.Sprintf("must be %q or %q", services.WalletKindExternal, services.WalletKindInApp)
+	}
+	if len(awr.Label.String) > maxWalletLabelLength {
+		fields["label"] = fmt.Sprintf("must be at most %d bytes long", maxWalletLabelLength)
+	}
+	if len(fields) != 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(ValidationErrorResponse{
+			ErrorMessage: "invalid fields in request",
+			Fields:       fields,
+		})
+	}
+
+	addr := common.HexToAddress(awr.Address)
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := services.FindUser(c.Context(), tx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	exists, err := models.UserWalletExists(c.Context(), tx, userID, addr.Bytes())
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	if exists {
+		return errorResponseHandler(c, errors.New("wallet already added"), fiber.StatusConflict)
+	}
+
+	wallet := models.UserWallet{
+		UserID:  userID,
+		Address: addr.Bytes(),
+		Kind:    awr.Kind,
+		Label:   awr.Label,
+	}
+
+	if err := wallet.Insert(c.Context(), tx, boil.Infer()); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(formatWallet(&wallet))
+}
+
+// RemoveWallet godoc
+// @Summary Remove a wallet from the authenticated user. The primary wallet can't be removed.
+// @Param address path string true "Wallet address"
+// @Success 204
+// @Failure 400 {object} controllers.ErrorResponse
+// @Failure 404 {object} controllers.ErrorResponse
+// @Failure 409 {object} controllers.ErrorResponse "Returned if the wallet is the primary one."
+// @Failure 500 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user/wallets/{address} [delete]
+func (d *UserController) RemoveWallet(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	addr, err := walletParam(c)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusBadRequest)
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := services.FindUser(c.Context(), tx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	wallet, err := models.FindUserWallet(c.Context(), tx, userID, addr.Bytes())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No wallet with address %s.", addr.Hex()))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if wallet.IsPrimary {
+		return errorResponseHandler(c, errors.New("can't remove the primary wallet"), fiber.StatusConflict)
+	}
+
+	if _, err := wallet.Delete(c.Context(), tx); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// SetPrimaryWallet godoc
+// @Summary Make a confirmed wallet the authenticated user's primary wallet.
+// @Description The primary wallet is the address reported by /v1/user and used for referrals.
+// @Param address path string true "Wallet address"
+// @Success 204
+// @Failure 400 {object} controllers.ErrorResponse
+// @Failure 404 {object} controllers.ErrorResponse
+// @Failure 409 {object} controllers.ErrorResponse "Returned if the wallet is unconfirmed."
+// @Failure 500 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user/wallets/{address}/primary [post]
+func (d *UserController) SetPrimaryWallet(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	addr, err := walletParam(c)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusBadRequest)
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	user, err := models.Users(
+		models.UserWhere.ID.EQ(userID),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.For("UPDATE"),
+	).One(c.Context(), tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	wallet, err := models.FindUserWallet(c.Context(), tx, userID, addr.Bytes())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No wallet with address %s.", addr.Hex()))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if !wallet.ConfirmedAt.Valid {
+		return errorResponseHandler(c, errors.New("wallet not confirmed"), fiber.StatusConflict)
+	}
+
+	if wallet.IsPrimary {
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+
+	if err := services.SetPrimaryWallet(c.Context(), tx, user, wallet); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	// Consumers know the user by their primary address.
+	if err := services.EnqueueUserEvent(c.Context(), tx, services.UserWeb3ConfirmedEventType, services.UserEventData{
+		UserID:          userID,
+		EthereumAddress: addr.Hex(),
+	}); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := d.ensureReferralCode(c.Context(), user); err != nil {
+		d.log.Err(err).Str("userId", userID).Msg("Failed to generate referral code.")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,317 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/users-api/internal/services"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	nonceAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	nonceLength   = 16
+
+	challengeStatement = "Confirm ownership of this wallet for your DIMO account."
+)
+
+type ChallengeRequest struct {
+	// Address is the Ethereum address to be confirmed. If omitted, the user's current
+	// unconfirmed address is used.
+	Address string `json:"address" example:"0x71C7656EC7ab88b098defB751B7401B5f6d8976F"`
+}
+
+type ChallengeResponse struct {
+	// Challenge is the message to be signed.
+	Challenge string `json:"challenge"`
+	// ExpiresAt is the time at which the signed challenge will no longer be accepted.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type ConfirmEthereumRequest struct {
+	// Signature is the result of signing the provided challenge message using the address in
+	// question.
+	Signature string `json:"signature"`
+}
+
+// siweMessage builds an EIP-4361 (Sign-In with Ethereum) message. The address always
+// sits on the second line, which is where submission reads it back from.
+// See https://eips.ethereum.org/EIPS/eip-4361
+func siweMessage(domain string, addr common.Address, uri string, chainID int64, nonce string, issuedAt, expiresAt time.Time) string {
+	return fmt.Sprintf(
+		"%s wants you to sign in with your Ethereum account:\n%s\n\n%s\n\nURI: %s\nVersion: 1\nChain ID: %d\nNonce: %s\nIssued At: %s\nExpiration Time: %s",
+		domain,
+		addr.Hex(),
+		challengeStatement,
+		uri,
+		chainID,
+		nonce,
+		issuedAt.UTC().Format(time.RFC3339),
+		expiresAt.UTC().Format(time.RFC3339),
+	)
+}
+
+// siweAddress extracts the address from a message produced by siweMessage.
+func siweAddress(msg string) (common.Address, error) {
+	lines := strings.SplitN(msg, "\n", 3)
+	if len(lines) < 2 || !common.IsHexAddress(lines[1]) {
+		return common.Address{}, errors.New("challenge message does not contain an address")
+	}
+	return common.HexToAddress(lines[1]), nil
+}
+
+// GenerateEthereumChallenge godoc
+// @Summary Generate a challenge message for the user to sign.
+// @Accept json
+// @Param challengeRequest body controllers.ChallengeRequest false "Address to confirm"
+// @Success 200 {object} controllers.ChallengeResponse
+// @Failure 400 {object} controllers.ErrorResponse
+// @Failure 500 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user/web3/challenge/generate [post]
+func (d *UserController) GenerateEthereumChallenge(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	var cr ChallengeRequest
+	if len(c.Body()) != 0 {
+		if err := c.BodyParser(&cr); err != nil {
+			return errorResponseHandler(c, errors.New("couldn't parse body"), fiber.StatusBadRequest)
+		}
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	user, err := services.FindUser(c.Context(), tx, userID, qm.For("UPDATE"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	var addr common.Address
+	switch {
+	case cr.Address != "":
+		if !common.IsHexAddress(cr.Address) {
+			return errorResponseHandler(c, fmt.Errorf("invalid ethereum address %q", cr.Address), fiber.StatusBadRequest)
+		}
+		addr = common.HexToAddress(cr.Address)
+	case len(user.EthereumAddress.Bytes) == common.AddressLength:
+		addr = common.BytesToAddress(user.EthereumAddress.Bytes)
+	default:
+		return errorResponseHandler(c, errors.New("no ethereum address to confirm"), fiber.StatusBadRequest)
+	}
+
+	// With a confirmed primary wallet, further addresses are confirmed as additional wallets.
+	if user.EthereumConfirmed && bytes.Equal(addr.Bytes(), user.EthereumAddress.Bytes) {
+		return errorResponseHandler(c, errors.New("ethereum address already confirmed"), fiber.StatusBadRequest)
+	}
+
+	nonce, err := randomString(nonceAlphabet, nonceLength)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(d.allowedLateness)
+
+	// The domain and URI are configured rather than taken from the request, which can
+	// claim any host.
+	challenge := siweMessage(d.siweURL.Host, addr, d.siweURL.String(), d.Settings.ChainID, nonce, now, expiresAt)
+
+	user.EthereumChallenge = null.StringFrom(challenge)
+	user.EthereumChallengeSent = null.TimeFrom(now)
+
+	if _, err := user.Update(c.Context(), tx, boil.Whitelist(models.UserColumns.EthereumChallenge, models.UserColumns.EthereumChallengeSent)); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	return c.JSON(ChallengeResponse{
+		Challenge: challenge,
+		ExpiresAt: expiresAt,
+	})
+}
+
+// SubmitEthereumChallenge godoc
+// @Summary Confirm ownership of an ethereum address by submitting a signature
+// @Description If the user already has a confirmed address, this one is added as another wallet.
+// @Accept json
+// @Param confirmEthereumRequest body controllers.ConfirmEthereumRequest true "Signed challenge message"
+// @Success 204
+// @Failure 400 {object} controllers.ErrorResponse
+// @Failure 500 {object} controllers.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/user/web3/challenge/submit [post]
+func (d *UserController) SubmitEthereumChallenge(c *fiber.Ctx) error {
+	userID := getUserID(c)
+
+	var cer ConfirmEthereumRequest
+	if err := c.BodyParser(&cer); err != nil {
+		return errorResponseHandler(c, errors.New("couldn't parse body"), fiber.StatusBadRequest)
+	}
+
+	sig, err := hexutil.Decode(cer.Signature)
+	if err != nil {
+		return errorResponseHandler(c, errors.New("signature is not valid hex"), fiber.StatusBadRequest)
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+	defer tx.Rollback() //nolint
+
+	user, err := services.FindUser(c.Context(), tx, userID, qm.For("UPDATE"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No user with id %q.", userID))
+		}
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if !user.EthereumChallenge.Valid || !user.EthereumChallengeSent.Valid {
+		return errorResponseHandler(c, errors.New("ethereum challenge never generated"), fiber.StatusBadRequest)
+	}
+
+	if time.Since(user.EthereumChallengeSent.Time) > d.allowedLateness {
+		return errorResponseHandler(c, errors.New("ethereum challenge expired"), fiber.StatusBadRequest)
+	}
+
+	addr, err := siweAddress(user.EthereumChallenge.String)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	ok, err := d.verifySignature(c.Context(), addr, user.EthereumChallenge.String, sig)
+	if err != nil {
+		d.log.Err(err).Str("userId", userID).Msg("Failed to verify contract signature.")
+		return errorResponseHandler(c, errors.New("failed to verify signature"), fiber.StatusInternalServerError)
+	}
+	if !ok {
+		return errorResponseHandler(c, errors.New("signature does not match address"), fiber.StatusBadRequest)
+	}
+
+	if user.EthereumConfirmed && bytes.Equal(addr.Bytes(), user.EthereumAddress.Bytes) {
+		return errorResponseHandler(c, errors.New("ethereum address already confirmed"), fiber.StatusBadRequest)
+	}
+
+	user.EthereumChallenge = null.StringFromPtr(nil)
+	user.EthereumChallengeSent = null.TimeFromPtr(nil)
+
+	if _, err := user.Update(c.Context(), tx, boil.Whitelist(models.UserColumns.EthereumChallenge, models.UserColumns.EthereumChallengeSent)); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if user.EthereumConfirmed {
+		// An additional wallet. The primary one stays as it is.
+		if _, err := services.ConfirmWallet(c.Context(), tx, userID, addr, services.WalletKindExternal); err != nil {
+			return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+		}
+
+		if err := services.EnqueueUserEvent(c.Context(), tx, services.UserWeb3ConfirmedEventType, services.UserEventData{
+			UserID:          userID,
+			EthereumAddress: addr.Hex(),
+		}); err != nil {
+			return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+		}
+
+		if err := d.stampExistingHoldings(c.Context(), addr); err != nil {
+			d.log.Err(err).Str("userId", userID).Msg("Failed to check holdings of newly confirmed address.")
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+
+	kind := services.WalletKindExternal
+	if user.InAppWallet {
+		kind = services.WalletKindInApp
+	}
+
+	wallet, err := services.ConfirmWallet(c.Context(), tx, userID, addr, kind)
+	if err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := services.SetPrimaryWallet(c.Context(), tx, user, wallet); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := services.EnqueueUserEvent(c.Context(), tx, services.UserWeb3ConfirmedEventType, services.UserEventData{
+		UserID:          userID,
+		EthereumAddress: addr.Hex(),
+	}); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorResponseHandler(c, err, fiber.StatusInternalServerError)
+	}
+
+	if err := d.ensureReferralCode(c.Context(), user); err != nil {
+		d.log.Err(err).Str("userId", userID).Msg("Failed to generate referral code.")
+	}
+
+	if err := d.stampExistingHoldings(c.Context(), addr); err != nil {
+		d.log.Err(err).Str("userId", userID).Msg("Failed to check holdings of newly confirmed address.")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// stampExistingHoldings marks a newly confirmed address as used if it already owns NFTs.
+// The indexer only sees transfers, and may have seen this address's before it was ours.
+func (d *UserController) stampExistingHoldings(ctx context.Context, addr common.Address) error {
+	holdings, err := d.chain.Holdings(ctx, []common.Address{addr})
+	if err != nil {
+		return err
+	}
+
+	if holdings[0].Vehicles.Sign() > 0 || holdings[0].AftermarketDevices.Sign() > 0 {
+		_, err = services.StampWeb3Used(ctx, d.dbs.DBS().Writer, addr)
+	}
+	return err
+}
+
+// verifySignature checks that sig is a personal_sign signature of msg by addr. If it
+// isn't, we fall back to asking addr, as a contract wallet, to validate it per EIP-1271.
+func (d *UserController) verifySignature(ctx context.Context, addr common.Address, msg string, sig []byte) (bool, error) {
+	hash := accounts.TextHash([]byte(msg))
+
+	if len(sig) == crypto.SignatureLength {
+		ecSig := bytes.Clone(sig)
+		// Wallets usually produce v in {27, 28}, but go-ethereum expects {0, 1}.
+		if ecSig[crypto.RecoveryIDOffset] >= 27 {
+			ecSig[crypto.RecoveryIDOffset] -= 27
+		}
+		if pub, err := crypto.SigToPub(hash, ecSig); err == nil && crypto.PubkeyToAddress(*pub) == addr {
+			return true, nil
+		}
+	}
+
+	return d.chain.IsValidContractSignature(ctx, addr, common.Hash(hash), sig)
+}
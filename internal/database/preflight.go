@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// EmailConflict is a set of users whose confirmed email addresses differ only by case.
+// These block the unique index on lower(email_address).
+type EmailConflict struct {
+	// Email is the lowercased address.
+	Email string
+	Users []*models.User
+}
+
+// FindEmailConflicts lists every group of users that share a confirmed email address,
+// ignoring case. Within a group, users are oldest first.
+func FindEmailConflicts(ctx context.Context, exec boil.ContextExecutor) ([]EmailConflict, error) {
+	users, err := models.Users(
+		models.UserWhere.EmailConfirmed.EQ(true),
+		qm.Where(`lower(email_address) IN (
+			SELECT lower(email_address) FROM users_api.users
+			WHERE email_confirmed AND email_address IS NOT NULL
+			GROUP BY 1 HAVING count(*) > 1)`),
+		qm.OrderBy("lower(email_address), created_at, id"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []EmailConflict
+
+	for _, u := range users {
+		email := strings.ToLower(u.EmailAddress.String)
+		if len(out) == 0 || out[len(out)-1].Email != email {
+			out = append(out, EmailConflict{Email: email})
+		}
+		last := &out[len(out)-1]
+		last.Users = append(last.Users, u)
+	}
+
+	return out, nil
+}
+
+// WriteEmailConflicts prints a table of the conflicts, one user per line.
+func WriteEmailConflicts(w io.Writer, conflicts []EmailConflict) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "EMAIL\tUSER ID\tSTORED ADDRESS\tCREATED AT\tETHEREUM ADDRESS")
+
+	for _, c := range conflicts {
+		for _, u := range c.Users {
+			eth := ""
+			if u.EthereumConfirmed && len(u.EthereumAddress.Bytes) == common.AddressLength {
+				eth = common.BytesToAddress(u.EthereumAddress.Bytes).Hex()
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", c.Email, u.ID, u.EmailAddress.String, u.CreatedAt.Format(time.RFC3339), eth)
+		}
+	}
+
+	return tw.Flush()
+}
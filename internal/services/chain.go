@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/DIMO-Network/users-api/internal/config"
+	"github.com/DIMO-Network/users-api/internal/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// chainCallTimeout bounds each individual RPC, on top of any deadline the caller has.
+const chainCallTimeout = 10 * time.Second
+
+// erc1271MagicValue is returned by isValidSignature when a contract wallet accepts a
+// signature. See https://eips.ethereum.org/EIPS/eip-1271
+var erc1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+// ChainBackend is the part of an Ethereum client that we use. It's satisfied by
+// *ethclient.Client and by the client of go-ethereum's simulated backend.
+type ChainBackend interface {
+	bind.ContractCaller
+}
+
+// ChainClient reads the DIMO contracts over a single, long-lived RPC connection. It's safe
+// for concurrent use.
+type ChainClient struct {
+	backend    ChainBackend
+	timeout    time.Duration
+	vehicleNFT *contracts.MultiPrivilegeCaller
+	adNFT      *contracts.MultiPrivilegeCaller
+	token      *contracts.TokenCaller
+
+	// multicall is nil if no Multicall3 address is configured.
+	multicall   *contracts.Multicall3Caller
+	vehicleAddr common.Address
+	adAddr      common.Address
+	tokenAddr   common.Address
+	nftABI      *abi.ABI
+	tokenABI    *abi.ABI
+}
+
+// DialEthereum connects to the node at url. HTTP connections are kept alive and shared
+// between requests.
+func DialEthereum(ctx context.Context, url string) (*ethclient.Client, error) {
+	hc := &http.Client{
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+
+	rc, err := rpc.DialOptions(ctx, url, rpc.WithHTTPClient(hc))
+	if err != nil {
+		return nil, err
+	}
+
+	return ethclient.NewClient(rc), nil
+}
+
+func NewChainClient(backend ChainBackend, settings *config.Settings) (*ChainClient, error) {
+	v, err := contracts.NewMultiPrivilegeCaller(common.HexToAddress(settings.VehicleNFTAddr), backend)
+	if err != nil {
+		return nil, err
+	}
+
+	ad, err := contracts.NewMultiPrivilegeCaller(common.HexToAddress(settings.ADNFTAddr), backend)
+	if err != nil {
+		return nil, err
+	}
+
+	tok, err := contracts.NewTokenCaller(common.HexToAddress(settings.TokenAddr), backend)
+	if err != nil {
+		return nil, err
+	}
+
+	nftABI, err := contracts.MultiPrivilegeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenABI, err := contracts.TokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ChainClient{
+		backend:     backend,
+		timeout:     chainCallTimeout,
+		vehicleNFT:  v,
+		adNFT:       ad,
+		token:       tok,
+		vehicleAddr: common.HexToAddress(settings.VehicleNFTAddr),
+		adAddr:      common.HexToAddress(settings.ADNFTAddr),
+		tokenAddr:   common.HexToAddress(settings.TokenAddr),
+		nftABI:      nftABI,
+		tokenABI:    tokenABI,
+	}
+
+	if settings.MulticallAddr != "" {
+		c.multicall, err = contracts.NewMulticall3Caller(common.HexToAddress(settings.MulticallAddr), backend)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (c *ChainClient) callOpts(ctx context.Context) (*bind.CallOpts, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	return &bind.CallOpts{Context: ctx}, cancel
+}
+
+// VehicleBalance returns the number of vehicle NFTs owned by the address.
+func (c *ChainClient) VehicleBalance(ctx context.Context, owner common.Address) (*big.Int, error) {
+	opts, cancel := c.callOpts(ctx)
+	defer cancel()
+	return c.vehicleNFT.BalanceOf(opts, owner)
+}
+
+// AftermarketDeviceBalance returns the number of aftermarket device NFTs owned by the address.
+func (c *ChainClient) AftermarketDeviceBalance(ctx context.Context, owner common.Address) (*big.Int, error) {
+	opts, cancel := c.callOpts(ctx)
+	defer cancel()
+	return c.adNFT.BalanceOf(opts, owner)
+}
+
+// TokenBalance returns the address's balance of the DIMO token.
+func (c *ChainClient) TokenBalance(ctx context.Context, owner common.Address) (*big.Int, error) {
+	opts, cancel := c.callOpts(ctx)
+	defer cancel()
+	return c.token.BalanceOf(opts, owner)
+}
+
+// WalletHoldings is what an address owns of the DIMO NFTs and token.
+type WalletHoldings struct {
+	Vehicles           *big.Int
+	AftermarketDevices *big.Int
+	Tokens             *big.Int
+}
+
+// Holdings returns the holdings of each owner, in the same order. With Multicall3
+// configured this is a single RPC; otherwise we make three calls per owner.
+func (c *ChainClient) Holdings(ctx context.Context, owners []common.Address) ([]WalletHoldings, error) {
+	if len(owners) == 0 {
+		return nil, nil
+	}
+
+	if c.multicall == nil {
+		return c.sequentialHoldings(ctx, owners)
+	}
+
+	calls := make([]contracts.Multicall3Call3, 0, 3*len(owners))
+
+	for _, owner := range owners {
+		nftData, err := c.nftABI.Pack("balanceOf", owner)
+		if err != nil {
+			return nil, err
+		}
+		tokData, err := c.tokenABI.Pack("balanceOf", owner)
+		if err != nil {
+			return nil, err
+		}
+
+		calls = append(calls,
+			contracts.Multicall3Call3{Target: c.vehicleAddr, CallData: nftData},
+			contracts.Multicall3Call3{Target: c.adAddr, CallData: nftData},
+			contracts.Multicall3Call3{Target: c.tokenAddr, CallData: tokData},
+		)
+	}
+
+	opts, cancel := c.callOpts(ctx)
+	defer cancel()
+
+	results, err := c.multicall.Aggregate3(opts, calls)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(results), len(calls))
+	}
+
+	unpack := func(a *abi.ABI, r contracts.Multicall3Result) (*big.Int, error) {
+		out, err := a.Unpack("balanceOf", r.ReturnData)
+		if err != nil {
+			return nil, err
+		}
+		return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+	}
+
+	holdings := make([]WalletHoldings, len(owners))
+
+	for i := range owners {
+		h := &holdings[i]
+		if h.Vehicles, err = unpack(c.nftABI, results[3*i]); err != nil {
+			return nil, err
+		}
+		if h.AftermarketDevices, err = unpack(c.nftABI, results[3*i+1]); err != nil {
+			return nil, err
+		}
+		if h.Tokens, err = unpack(c.tokenABI, results[3*i+2]); err != nil {
+			return nil, err
+		}
+	}
+
+	return holdings, nil
+}
+
+func (c *ChainClient) sequentialHoldings(ctx context.Context, owners []common.Address) ([]WalletHoldings, error) {
+	holdings := make([]WalletHoldings, len(owners))
+
+	for i, owner := range owners {
+		h := &holdings[i]
+		var err error
+		if h.Vehicles, err = c.VehicleBalance(ctx, owner); err != nil {
+			return nil, err
+		}
+		if h.AftermarketDevices, err = c.AftermarketDeviceBalance(ctx, owner); err != nil {
+			return nil, err
+		}
+		if h.Tokens, err = c.TokenBalance(ctx, owner); err != nil {
+			return nil, err
+		}
+	}
+
+	return holdings, nil
+}
+
+// IsValidContractSignature asks wallet, as a contract wallet, whether sig is a valid
+// signature of hash per EIP-1271. Addresses without code are never valid.
+func (c *ChainClient) IsValidContractSignature(ctx context.Context, wallet common.Address, hash common.Hash, sig []byte) (bool, error) {
+	opts, cancel := c.callOpts(ctx)
+	defer cancel()
+
+	code, err := c.backend.CodeAt(opts.Context, wallet, nil)
+	if err != nil {
+		return false, err
+	}
+	if len(code) == 0 {
+		return false, nil
+	}
+
+	caller, err := contracts.NewERC1271Caller(wallet, c.backend)
+	if err != nil {
+		return false, err
+	}
+
+	magic, err := caller.IsValidSignature(opts, hash, sig)
+	if err != nil {
+		if opts.Context.Err() != nil {
+			return false, err
+		}
+		// Reverting is a legitimate way for a wallet to reject a signature.
+		return false, nil
+	}
+
+	return magic == erc1271MagicValue, nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DIMO-Network/users-api/internal/config"
+	"github.com/DIMO-Network/users-api/internal/contracts/contractstest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainClient_Holdings(t *testing.T) {
+	ctx := context.Background()
+
+	chain := contractstest.NewChain(t)
+
+	vehicleOwner := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	deviceOwner := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	tokenHolder := common.HexToAddress("0x0000000000000000000000000000000000000003")
+	unused := common.HexToAddress("0x0000000000000000000000000000000000000004")
+
+	chain.MintNFT(chain.VehicleNFT, vehicleOwner)
+	chain.MintNFT(chain.VehicleNFT, vehicleOwner)
+	chain.MintNFT(chain.AftermarketDevice, deviceOwner)
+	chain.TransferNFT(chain.VehicleNFT, vehicleOwner, deviceOwner, 2)
+	chain.MintTokens(tokenHolder, 100)
+
+	owners := []common.Address{vehicleOwner, deviceOwner, tokenHolder, unused}
+	// Vehicles, aftermarket devices and tokens.
+	want := [][3]int64{{1, 0, 0}, {1, 1, 0}, {0, 0, 100}, {0, 0, 0}}
+
+	for _, multicall := range []string{"", chain.Multicall.Hex()} {
+		c, err := NewChainClient(chain.Backend.Client(), &config.Settings{
+			VehicleNFTAddr: chain.VehicleNFT.Hex(),
+			ADNFTAddr:      chain.AftermarketDevice.Hex(),
+			TokenAddr:      chain.Token.Hex(),
+			MulticallAddr:  multicall,
+		})
+		require.NoError(t, err)
+
+		holdings, err := c.Holdings(ctx, owners)
+		require.NoError(t, err)
+		got := make([][3]int64, len(holdings))
+		for i, h := range holdings {
+			got[i] = [3]int64{h.Vehicles.Int64(), h.AftermarketDevices.Int64(), h.Tokens.Int64()}
+		}
+		assert.Equal(t, want, got, "Multicall %q", multicall)
+	}
+}
+
+func TestChainClient_IsValidContractSignature(t *testing.T) {
+	chain := contractstest.NewChain(t)
+
+	c, err := NewChainClient(chain.Backend.Client(), &config.Settings{
+		VehicleNFTAddr: chain.VehicleNFT.Hex(),
+		ADNFTAddr:      chain.AftermarketDevice.Hex(),
+		TokenAddr:      chain.Token.Hex(),
+	})
+	require.NoError(t, err)
+
+	// Neither an account without code nor a contract that doesn't implement EIP-1271 accepts
+	// signatures.
+	for _, wallet := range []common.Address{common.HexToAddress("0x0000000000000000000000000000000000000001"), chain.Token} {
+		ok, err := c.IsValidContractSignature(context.Background(), wallet, common.Hash{}, []byte{0x01})
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+}
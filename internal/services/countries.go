@@ -0,0 +1,29 @@
+package services
+
+import (
+	_ "embed"
+
+	"github.com/goccy/go-json"
+)
+
+//go:embed country_codes.json
+var rawCountryCodes []byte
+
+// countryCodes is the set of valid ISO 3166-1 alpha-3 codes.
+var countryCodes = func() map[string]struct{} {
+	var codes []string
+	if err := json.Unmarshal(rawCountryCodes, &codes); err != nil {
+		panic(err)
+	}
+	out := make(map[string]struct{}, len(codes))
+	for _, c := range codes {
+		out[c] = struct{}{}
+	}
+	return out
+}()
+
+// ValidCountryCode reports whether code is an upper-case ISO 3166-1 alpha-3 code.
+func ValidCountryCode(code string) bool {
+	_, ok := countryCodes[code]
+	return ok
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/users-api/internal/config"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	defaultDeletionGracePeriod = 30 * 24 * time.Hour
+
+	defaultPurgeInterval  = time.Hour
+	defaultPurgeBatchSize = 100
+)
+
+// purgeQuery hard-deletes a batch of users whose grace period ended before $1. Rows locked
+// by a concurrent restore are left for next time.
+const purgeQuery = `DELETE FROM users_api.users WHERE id IN (
+	SELECT id FROM users_api.users WHERE deleted_at < $1 ORDER BY deleted_at LIMIT $2 FOR UPDATE SKIP LOCKED
+)`
+
+var usersPurged = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "users_api",
+	Name:      "users_purged_total",
+	Help:      "Deleted users removed for good after their grace period.",
+})
+
+// DeletionGracePeriod returns how long deleted users can be restored.
+func DeletionGracePeriod(settings *config.Settings) time.Duration {
+	if settings.DeletionGracePeriod <= 0 {
+		return defaultDeletionGracePeriod
+	}
+	return time.Duration(settings.DeletionGracePeriod) * time.Second
+}
+
+// FindUser is models.FindUser, except that deleted users aren't found.
+func FindUser(ctx context.Context, exec boil.ContextExecutor, userID string, mods ...qm.QueryMod) (*models.User, error) {
+	return models.Users(append([]qm.QueryMod{
+		models.UserWhere.ID.EQ(userID),
+		models.UserWhere.DeletedAt.IsNull(),
+	}, mods...)...).One(ctx, exec)
+}
+
+// UserPurger hard-deletes users whose deletion grace period is over.
+type UserPurger struct {
+	dbs       db.Store
+	grace     time.Duration
+	interval  time.Duration
+	batchSize int
+	logger    *zerolog.Logger
+}
+
+func NewUserPurger(dbs db.Store, settings *config.Settings, logger *zerolog.Logger) *UserPurger {
+	return &UserPurger{
+		dbs:       dbs,
+		grace:     DeletionGracePeriod(settings),
+		interval:  defaultPurgeInterval,
+		batchSize: defaultPurgeBatchSize,
+		logger:    logger,
+	}
+}
+
+// Run purges until the context is cancelled.
+func (p *UserPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := p.Purge(ctx)
+		if err != nil {
+			p.logger.Err(err).Msg("Failed to purge deleted users.")
+		} else if n > 0 {
+			p.logger.Info().Int64("count", n).Msg("Purged deleted users.")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge deletes every user whose grace period is over, in batches, and returns the
+// number deleted.
+func (p *UserPurger) Purge(ctx context.Context) (int64, error) {
+	cutoff := time.Now().Add(-p.grace)
+
+	var total int64
+	for {
+		res, err := queries.Raw(purgeQuery, cutoff, p.batchSize).ExecContext(ctx, p.dbs.DBS().Writer)
+		if err != nil {
+			return total, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+
+		total += n
+		usersPurged.Add(float64(n))
+
+		if n < int64(p.batchSize) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/users-api/internal/config"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func (s *ServicesTestSuite) TestUserPurger() {
+	ctx := context.Background()
+
+	now := time.Now()
+
+	users := []*models.User{
+		{ID: "expired", CreatedAt: now, DeletedAt: null.TimeFrom(now.Add(-2 * time.Hour))},
+		{ID: "expired2", CreatedAt: now, DeletedAt: null.TimeFrom(now.Add(-3 * time.Hour))},
+		{ID: "grace", CreatedAt: now, DeletedAt: null.TimeFrom(now.Add(-time.Minute))},
+		{ID: "live", CreatedAt: now, ReferringUserID: null.StringFrom("expired")},
+	}
+	for _, u := range users {
+		s.Require().NoError(u.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+	}
+
+	p := NewUserPurger(s.dbs, &config.Settings{DeletionGracePeriod: 3600}, s.logger)
+	p.batchSize = 1
+
+	n, err := p.Purge(ctx)
+	s.Require().NoError(err)
+	s.EqualValues(2, n)
+
+	left, err := models.Users().All(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+
+	var ids []string
+	for _, u := range left {
+		ids = append(ids, u.ID)
+	}
+	s.ElementsMatch([]string{"grace", "live"}, ids)
+
+	_, err = FindUser(ctx, s.dbs.DBS().Reader, "grace")
+	s.Require().Error(err)
+
+	live, err := FindUser(ctx, s.dbs.DBS().Reader, "live")
+	s.Require().NoError(err)
+	s.False(live.ReferringUserID.Valid)
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/DIMO-Network/devices-api/pkg/grpc"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+)
+
+// DevicesAPI is the part of the devices-api client that we use.
+type DevicesAPI interface {
+	ListUserDevicesForUser(ctx context.Context, in *pb.ListUserDevicesForUserRequest, opts ...grpc.CallOption) (*pb.ListUserDevicesForUserResponse, error)
+}
+
+// UserDeviceCount returns the number of devices that the user still has in devices-api.
+// A user can't be deleted until this is zero.
+func UserDeviceCount(ctx context.Context, client DevicesAPI, userID string) (int, error) {
+	resp, err := client.ListUserDevicesForUser(ctx, &pb.ListUserDevicesForUserRequest{UserId: userID})
+	if err != nil {
+		return 0, err
+	}
+	return len(resp.UserDevices), nil
+}
+
+// AftermarketDevicesAPI is the part of the devices-api aftermarket device client that we use.
+type AftermarketDevicesAPI interface {
+	ListAftermarketDevicesForUser(ctx context.Context, in *pb.ListAftermarketDevicesForUserRequest, opts ...grpc.CallOption) (*pb.ListAftermarketDevicesForUserResponse, error)
+}
+
+// DevicesWeb3Used asks devices-api whether the user has any minted vehicles or paired
+// aftermarket devices. This is how web3 usage was determined before Web3UsageIndexer.
+func DevicesWeb3Used(ctx context.Context, devices DevicesAPI, ams AftermarketDevicesAPI, userID string) (bool, error) {
+	uds, err := devices.ListUserDevicesForUser(ctx, &pb.ListUserDevicesForUserRequest{UserId: userID})
+	if err != nil {
+		return false, fmt.Errorf("couldn't retrieve user's vehicles: %w", err)
+	}
+
+	for _, ud := range uds.UserDevices {
+		if ud.TokenId != nil {
+			return true, nil
+		}
+	}
+
+	resp, err := ams.ListAftermarketDevicesForUser(ctx, &pb.ListAftermarketDevicesForUserRequest{UserId: userID})
+	if err != nil {
+		return false, fmt.Errorf("couldn't retrieve user's aftermarket devices: %w", err)
+	}
+
+	for _, am := range resp.AftermarketDevices {
+		if len(am.OwnerAddress) == common.AddressLength {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+var ErrInvalidEmailAddress = errors.New("invalid email address")
+
+// foldPlusSQL drops a "+tag" from a lowercased address column the way Normalize does with
+// FoldPlus. The index users_confirmed_email_address_folded_idx is on this expression.
+const foldPlusSQL = `regexp_replace(lower(%s), '^([^+@]+)\+[^@]*@', '\1@')`
+
+// EmailNormalizer puts email addresses into the form in which we store and look them up.
+type EmailNormalizer struct {
+	// FoldPlus drops any "+tag" suffix from the local part, so that plus addresses map to
+	// the underlying mailbox.
+	FoldPlus bool
+}
+
+// Normalize trims the address and lowercases its domain. The local part is left alone,
+// since it's case-sensitive in principle. The address must be a bare RFC 5322 addr-spec:
+// no display name, comments, or quoting.
+func (n EmailNormalizer) Normalize(addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+
+	parsed, err := mail.ParseAddress(addr)
+	if err != nil || parsed.Address != addr {
+		return "", ErrInvalidEmailAddress
+	}
+
+	at := strings.LastIndexByte(addr, '@')
+	local, domain := addr[:at], strings.ToLower(addr[at+1:])
+
+	if n.FoldPlus {
+		if i := strings.IndexByte(local, '+'); i > 0 {
+			local = local[:i]
+		}
+	}
+
+	return local + "@" + domain, nil
+}
+
+// LookupSQL is an expression for the stored address in column that can be compared with
+// lower() of a normalized address. Addresses stored before FoldPlus was turned on keep
+// their tags, so with FoldPlus the tags are dropped on this side too.
+func (n EmailNormalizer) LookupSQL(column string) string {
+	if n.FoldPlus {
+		return fmt.Sprintf(foldPlusSQL, column)
+	}
+	return "lower(" + column + ")"
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailNormalizer(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		foldPlus bool
+		out      string
+		invalid  bool
+	}{
+		{in: "steve@apple.com", out: "steve@apple.com"},
+		{in: "  Steve@Apple.COM\n", out: "Steve@apple.com"},
+		{in: "steve+dimo@apple.com", out: "steve+dimo@apple.com"},
+		{in: "steve+dimo@apple.com", foldPlus: true, out: "steve@apple.com"},
+		{in: "+dimo@apple.com", foldPlus: true, out: "+dimo@apple.com"},
+		{in: "not an email", invalid: true},
+		{in: "steve@", invalid: true},
+		{in: "Steve <steve@apple.com>", invalid: true},
+		{in: "steve@apple.com, tim@apple.com", invalid: true},
+		{in: "", invalid: true},
+	} {
+		out, err := EmailNormalizer{FoldPlus: tc.foldPlus}.Normalize(tc.in)
+		if tc.invalid {
+			assert.ErrorIs(t, err, ErrInvalidEmailAddress, tc.in)
+		} else if assert.NoError(t, err, tc.in) {
+			assert.Equal(t, tc.out, out, tc.in)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/shared"
+	"github.com/IBM/sarama"
+	"github.com/goccy/go-json"
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	UserDeletedEventType        = "zone.dimo.user.deleted"
+	UserRestoredEventType       = "zone.dimo.user.restored"
+	UserEmailConfirmedEventType = "zone.dimo.user.email.confirmed"
+	UserWeb3ConfirmedEventType  = "zone.dimo.user.web3.confirmed"
+	UserMigratedEventType       = "zone.dimo.user.migrated"
+	UserMergedEventType         = "zone.dimo.user.merged"
+	UserUpdatedEventType        = "zone.dimo.user.updated"
+
+	eventSource = "users-api"
+)
+
+// UserEventData is the payload of every user lifecycle event. Fields that don't apply to
+// a given event type are omitted.
+type UserEventData struct {
+	Timestamp       time.Time  `json:"timestamp"`
+	UserID          string     `json:"userId"`
+	EmailAddress    string     `json:"emailAddress,omitempty"`
+	EthereumAddress string     `json:"ethereumAddress,omitempty"`
+	MigratedAt      *time.Time `json:"migratedAt,omitempty"`
+	// MergedIntoID is the account a merged account now belongs to.
+	MergedIntoID string `json:"mergedIntoId,omitempty"`
+}
+
+// UserEvent is a CloudEvent describing a change to a user.
+type UserEvent = shared.CloudEvent[UserEventData]
+
+// NewUserEvent fills in the CloudEvent envelope for the given type and payload. The subject
+// is always the user ID.
+func NewUserEvent(eventType string, data UserEventData) *UserEvent {
+	return &UserEvent{
+		ID:          ksuid.New().String(),
+		Source:      eventSource,
+		SpecVersion: "1.0",
+		Subject:     data.UserID,
+		Time:        data.Timestamp,
+		Type:        eventType,
+		Data:        data,
+	}
+}
+
+// EventProducer publishes user events to downstream services.
+type EventProducer interface {
+	Emit(ctx context.Context, event *UserEvent) error
+}
+
+// KafkaEventProducer publishes events as JSON to a single Kafka topic, keyed by user ID so
+// that events for the same user land on the same partition.
+type KafkaEventProducer struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func NewKafkaEventProducer(brokers []string, topic string) (*KafkaEventProducer, error) {
+	kconf := sarama.NewConfig()
+	kconf.Version = sarama.V3_6_0_0
+	kconf.Producer.Return.Successes = true
+
+	p, err := sarama.NewSyncProducer(brokers, kconf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KafkaEventProducer{producer: p, topic: topic}, nil
+}
+
+func (p *KafkaEventProducer) Emit(_ context.Context, event *UserEvent) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: p.topic,
+		Key:   sarama.StringEncoder(event.Subject),
+		Value: sarama.ByteEncoder(b),
+	})
+	return err
+}
+
+func (p *KafkaEventProducer) Close() error {
+	return p.producer.Close()
+}
+
+// InMemoryEventProducer records emitted events. Useful for tests.
+type InMemoryEventProducer struct {
+	mu     sync.Mutex
+	events []*UserEvent
+}
+
+func (p *InMemoryEventProducer) Emit(_ context.Context, event *UserEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Events returns a copy of the events emitted so far, in order.
+func (p *InMemoryEventProducer) Events() []*UserEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]*UserEvent, len(p.events))
+	copy(out, p.events)
+	return out
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Export formats and statuses, for user_exports.
+const (
+	ExportFormatJSON = "json"
+	ExportFormatZIP  = "zip"
+
+	ExportStatusPending = "pending"
+	ExportStatusDone    = "done"
+	ExportStatusFailed  = "failed"
+	ExportStatusExpired = "expired"
+)
+
+const (
+	// ExportTTL is how long the result of an asynchronous export can be downloaded.
+	ExportTTL = 7 * 24 * time.Hour
+
+	defaultExportInterval = 10 * time.Second
+)
+
+var exportsCompleted = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "users_api",
+	Name:      "user_exports_total",
+	Help:      "User data exports produced, by mode and outcome.",
+}, []string{"mode", "status"})
+
+// MaskUserID hides all but a few characters at either end of a user ID.
+func MaskUserID(id string) string {
+	const keep = 3
+	if len(id) <= 2*keep {
+		return strings.Repeat("*", len(id))
+	}
+	return id[:keep] + "****" + id[len(id)-keep:]
+}
+
+// UserDataExport is everything we store about a user, as handed to them on request.
+type UserDataExport struct {
+	ExportedAt time.Time  `json:"exportedAt"`
+	User       ExportUser `json:"user"`
+	// EthereumAddress is the user's primary address, in hex.
+	EthereumAddress null.String              `json:"ethereumAddress"`
+	TOSAgreements   models.TosAgreementSlice `json:"tosAgreements"`
+	Referrer        *ExportReferrer          `json:"referrer"`
+	Referees        []ExportReferee          `json:"referees"`
+	Migration       ExportMigration          `json:"migration"`
+	Wallets         []ExportWallet           `json:"wallets"`
+	Identities      models.UserIdentitySlice `json:"identities"`
+}
+
+// ExportUser is the user's row, under the column names. Confirmation codes and challenges
+// are left out, as is anything identifying another user.
+type ExportUser struct {
+	ID                string      `json:"id"`
+	AuthProviderID    string      `json:"auth_provider_id"`
+	EmailAddress      null.String `json:"email_address"`
+	EmailConfirmed    bool        `json:"email_confirmed"`
+	EthereumConfirmed bool        `json:"ethereum_confirmed"`
+	InAppWallet       bool        `json:"in_app_wallet"`
+	CountryCode       null.String `json:"country_code"`
+	AgreedTOSAt       null.Time   `json:"agreed_tos_at"`
+	AgreedTOSVersion  null.String `json:"agreed_tos_version"`
+	ReferralCode      null.String `json:"referral_code"`
+	ReferredAt        null.Time   `json:"referred_at"`
+	MigratedAt        null.Time   `json:"migrated_at"`
+	Web3UsedAt        null.Time   `json:"web3_used_at"`
+	CreatedAt         time.Time   `json:"created_at"`
+}
+
+// ExportReferrer describes the user who referred the exported user. Their ID is masked,
+// since it's derived from their login.
+type ExportReferrer struct {
+	ID              string      `json:"id"`
+	EthereumAddress null.String `json:"ethereumAddress"`
+	ReferredAt      null.Time   `json:"referredAt"`
+}
+
+// ExportReferee describes a user the exported user referred. Their ID is masked, as in
+// GET /v1/user/referrals.
+type ExportReferee struct {
+	ID         string    `json:"id"`
+	ReferredAt null.Time `json:"referredAt"`
+}
+
+type ExportMigration struct {
+	Migrated   bool      `json:"migrated"`
+	MigratedAt null.Time `json:"migratedAt"`
+}
+
+type ExportWallet struct {
+	Address     string      `json:"address"`
+	Kind        string      `json:"kind"`
+	Label       null.String `json:"label"`
+	ConfirmedAt null.Time   `json:"confirmedAt"`
+	Primary     bool        `json:"primary"`
+	CreatedAt   time.Time   `json:"createdAt"`
+}
+
+// BuildUserExport gathers the data of a user that hasn't been deleted.
+func BuildUserExport(ctx context.Context, exec boil.ContextExecutor, userID string) (*UserDataExport, error) {
+	user, err := FindUser(ctx, exec, userID, qm.Load(models.UserRels.ReferringUser, models.UserWhere.DeletedAt.IsNull()))
+	if err != nil {
+		return nil, err
+	}
+
+	out := &UserDataExport{
+		ExportedAt: time.Now(),
+		User: ExportUser{
+			ID:                user.ID,
+			AuthProviderID:    user.AuthProviderID,
+			EmailAddress:      user.EmailAddress,
+			EmailConfirmed:    user.EmailConfirmed,
+			EthereumConfirmed: user.EthereumConfirmed,
+			InAppWallet:       user.InAppWallet,
+			CountryCode:       user.CountryCode,
+			AgreedTOSAt:       user.AgreedTosAt,
+			AgreedTOSVersion:  user.AgreedTosVersion,
+			ReferralCode:      user.ReferralCode,
+			ReferredAt:        user.ReferredAt,
+			MigratedAt:        user.MigratedAt,
+			Web3UsedAt:        user.Web3UsedAt,
+			CreatedAt:         user.CreatedAt,
+		},
+		Migration: ExportMigration{
+			Migrated:   user.MigratedAt.Valid,
+			MigratedAt: user.MigratedAt,
+		},
+	}
+
+	if len(user.EthereumAddress.Bytes) == common.AddressLength {
+		out.EthereumAddress = null.StringFrom(common.BytesToAddress(user.EthereumAddress.Bytes).Hex())
+	}
+
+	if ref := user.R.ReferringUser; ref != nil {
+		out.Referrer = &ExportReferrer{ID: MaskUserID(ref.ID), ReferredAt: user.ReferredAt}
+		if ref.EthereumConfirmed {
+			out.Referrer.EthereumAddress = null.StringFrom(common.BytesToAddress(ref.EthereumAddress.Bytes).Hex())
+		}
+	}
+
+	out.TOSAgreements, err = user.TosAgreements(
+		qm.OrderBy(models.TosAgreementColumns.AgreedAt),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	referees, err := user.ReferringUserUsers(
+		qm.Select(models.UserColumns.ID, models.UserColumns.ReferredAt),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.OrderBy(models.UserColumns.ReferredAt+", "+models.UserColumns.ID),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	out.Referees = make([]ExportReferee, len(referees))
+	for i, r := range referees {
+		out.Referees[i] = ExportReferee{ID: MaskUserID(r.ID), ReferredAt: r.ReferredAt}
+	}
+
+	wallets, err := user.UserWallets(
+		qm.OrderBy(models.UserWalletColumns.CreatedAt),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	out.Wallets = make([]ExportWallet, len(wallets))
+	for i, w := range wallets {
+		out.Wallets[i] = ExportWallet{
+			Address:     common.BytesToAddress(w.Address).Hex(),
+			Kind:        w.Kind,
+			Label:       w.Label,
+			ConfirmedAt: w.ConfirmedAt,
+			Primary:     w.IsPrimary,
+			CreatedAt:   w.CreatedAt,
+		}
+	}
+
+	out.Identities, err = user.UserIdentities(
+		qm.OrderBy(models.UserIdentityColumns.CreatedAt),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// ExportFileName is the name to offer an export in the given format under. The JSON
+// document inside a ZIP export has the JSON name.
+func ExportFileName(format string) string {
+	return "dimo-user-data." + format
+}
+
+// EncodeUserExport renders an export in the given format.
+func EncodeUserExport(export *UserDataExport, format string) ([]byte, error) {
+	doc, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case ExportFormatJSON:
+		return doc, nil
+	case ExportFormatZIP:
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+
+		w, err := zw.Create(ExportFileName(ExportFormatJSON))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(doc); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	default:
+		return nil, errors.New("unknown export format")
+	}
+}
+
+// ExportWorker produces asynchronous exports.
+type ExportWorker struct {
+	dbs      db.Store
+	interval time.Duration
+	logger   *zerolog.Logger
+}
+
+func NewExportWorker(dbs db.Store, logger *zerolog.Logger) *ExportWorker {
+	return &ExportWorker{
+		dbs:      dbs,
+		interval: defaultExportInterval,
+		logger:   logger,
+	}
+}
+
+// Run works through pending exports until the context is cancelled.
+func (w *ExportWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			ok, err := w.ProcessNext(ctx)
+			if err != nil {
+				w.logger.Err(err).Msg("Failed to process user export.")
+				break
+			}
+			if !ok {
+				break
+			}
+		}
+
+		if err := w.Expire(ctx); err != nil {
+			w.logger.Err(err).Msg("Failed to expire user exports.")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessNext produces the oldest pending export, if there is one, and reports whether
+// there was. An export that can't be built is marked as failed.
+func (w *ExportWorker) ProcessNext(ctx context.Context) (bool, error) {
+	tx, err := w.dbs.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback() //nolint
+
+	job, err := models.UserExports(
+		models.UserExportWhere.Status.EQ(ExportStatusPending),
+		qm.OrderBy(models.UserExportColumns.CreatedAt),
+		qm.For("UPDATE SKIP LOCKED"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	job.Status = ExportStatusDone
+	job.CompletedAt = null.TimeFrom(time.Now())
+
+	export, err := BuildUserExport(ctx, tx, job.UserID)
+	if err == nil {
+		job.Data.Bytes, err = EncodeUserExport(export, job.Format)
+		job.Data.Valid = err == nil
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = errors.New("user not found")
+		}
+		w.logger.Err(err).Str("exportId", job.ID).Str("userId", job.UserID).Msg("Failed to build user export.")
+		job.Status = ExportStatusFailed
+		job.Error = null.StringFrom(err.Error())
+	}
+
+	if _, err := job.Update(ctx, tx, boil.Infer()); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	exportsCompleted.WithLabelValues("async", job.Status).Inc()
+
+	return true, nil
+}
+
+// Expire drops the results of asynchronous exports older than ExportTTL. The rows stay as
+// the audit trail.
+func (w *ExportWorker) Expire(ctx context.Context) error {
+	_, err := models.UserExports(
+		models.UserExportWhere.Status.EQ(ExportStatusDone),
+		models.UserExportWhere.Async.EQ(true),
+		models.UserExportWhere.CompletedAt.LT(null.TimeFrom(time.Now().Add(-ExportTTL))),
+	).UpdateAll(ctx, w.dbs.DBS().Writer, models.M{
+		models.UserExportColumns.Status: ExportStatusExpired,
+		models.UserExportColumns.Data:   nil,
+	})
+	return err
+}
+
+// RecordSyncExport counts an export produced in the request that asked for it.
+func RecordSyncExport(status string) {
+	exportsCompleted.WithLabelValues("sync", status).Inc()
+}
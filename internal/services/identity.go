@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// ErrNoProvider is returned when resolving a login that doesn't name its provider.
+var ErrNoProvider = errors.New("login has no provider")
+
+// ResolveIdentity returns the ID of the user that a login acts as. The subject is a
+// token's sub claim, which is only unique per provider, so providerID is required. If the
+// login has no identity, the error is sql.ErrNoRows; such logins act as the user whose ID
+// is the subject.
+func ResolveIdentity(ctx context.Context, exec boil.ContextExecutor, providerID, subject string) (string, error) {
+	if providerID == "" {
+		return "", ErrNoProvider
+	}
+
+	identity, err := models.FindUserIdentity(ctx, exec, providerID, subject, models.UserIdentityColumns.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	return identity.UserID, nil
+}
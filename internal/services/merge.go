@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// ErrNoDuplicates is returned when merging an address that has fewer than two active
+// accounts.
+var ErrNoDuplicates = errors.New("no duplicate accounts for address")
+
+// MergeChoice is one value the survivor of a merge ends up with.
+type MergeChoice[T any] struct {
+	Value T `json:"value"`
+	// From is the ID of the account the value comes from.
+	From string `json:"from"`
+}
+
+// MergePlan describes how a group of accounts sharing a confirmed Ethereum address
+// collapse into one. The survivor is the account GetUser has always preferred: the one
+// with a confirmed email, then the oldest.
+type MergePlan struct {
+	EthereumAddress common.Address `json:"ethereumAddress"`
+	SurvivorID      string         `json:"survivorId"`
+	MergedIDs       []string       `json:"mergedIds"`
+
+	// Email is the first confirmed address, or failing that the first address.
+	Email MergeChoice[null.String] `json:"email"`
+	// ReferralCode is the first referral code, so the survivor keeps an existing one.
+	ReferralCode MergeChoice[null.String] `json:"referralCode"`
+	// Referrer is the earliest referral from outside the group.
+	Referrer MergeChoice[null.String] `json:"referrer"`
+	// AgreedTOSAt is the most recent agreement, along with its version.
+	AgreedTOSAt MergeChoice[null.Time] `json:"agreedTosAt"`
+	// MigratedAt is the earliest migration.
+	MigratedAt  MergeChoice[null.Time]   `json:"migratedAt"`
+	CountryCode MergeChoice[null.String] `json:"countryCode"`
+}
+
+// DuplicateAddresses lists confirmed Ethereum addresses shared by more than one active
+// account.
+func DuplicateAddresses(ctx context.Context, exec boil.ContextExecutor) ([]common.Address, error) {
+	var rows []struct {
+		EthereumAddress []byte `boil:"ethereum_address"`
+	}
+
+	err := models.NewQuery(
+		qm.Select(models.UserColumns.EthereumAddress),
+		qm.From(models.TableNames.Users),
+		models.UserWhere.EthereumConfirmed.EQ(true),
+		models.UserWhere.EthereumAddress.IsNotNull(),
+		models.UserWhere.MergedIntoID.IsNull(),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.GroupBy(models.UserColumns.EthereumAddress),
+		qm.Having("count(*) > 1"),
+		qm.OrderBy(models.UserColumns.EthereumAddress),
+	).Bind(ctx, exec, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]common.Address, len(rows))
+	for i, r := range rows {
+		out[i] = common.BytesToAddress(r.EthereumAddress)
+	}
+	return out, nil
+}
+
+func duplicateGroup(ctx context.Context, exec boil.ContextExecutor, addr common.Address, mods ...qm.QueryMod) (models.UserSlice, error) {
+	return models.Users(append([]qm.QueryMod{
+		models.UserWhere.EthereumAddress.EQ(null.BytesFrom(addr.Bytes())),
+		models.UserWhere.EthereumConfirmed.EQ(true),
+		models.UserWhere.MergedIntoID.IsNull(),
+		models.UserWhere.DeletedAt.IsNull(),
+		qm.OrderBy(models.UserColumns.EmailConfirmed + " DESC, " + models.UserColumns.CreatedAt + ", " + models.UserColumns.ID),
+	}, mods...)...).All(ctx, exec)
+}
+
+// PlanMerge works out how the accounts sharing addr would be merged, without changing
+// anything.
+func PlanMerge(ctx context.Context, exec boil.ContextExecutor, addr common.Address) (*MergePlan, error) {
+	group, err := duplicateGroup(ctx, exec, addr)
+	if err != nil {
+		return nil, err
+	}
+	return planMerge(addr, group)
+}
+
+// first returns the first user in group satisfying ok, or the survivor if there is none.
+func first(group models.UserSlice, ok func(*models.User) bool) *models.User {
+	for _, u := range group {
+		if ok(u) {
+			return u
+		}
+	}
+	return group[0]
+}
+
+// planMerge expects group to be ordered by preference, as duplicateGroup returns it.
+func planMerge(addr common.Address, group models.UserSlice) (*MergePlan, error) {
+	if len(group) < 2 {
+		return nil, ErrNoDuplicates
+	}
+
+	ids := make([]string, len(group))
+	for i, u := range group {
+		ids[i] = u.ID
+	}
+
+	plan := &MergePlan{
+		EthereumAddress: addr,
+		SurvivorID:      ids[0],
+		MergedIDs:       ids[1:],
+	}
+
+	email := first(group, func(u *models.User) bool { return u.EmailConfirmed && u.EmailAddress.Valid })
+	if !email.EmailConfirmed {
+		email = first(group, func(u *models.User) bool { return u.EmailAddress.Valid })
+	}
+	plan.Email = MergeChoice[null.String]{Value: email.EmailAddress, From: email.ID}
+
+	code := first(group, func(u *models.User) bool { return u.ReferralCode.Valid })
+	plan.ReferralCode = MergeChoice[null.String]{Value: code.ReferralCode, From: code.ID}
+
+	plan.Referrer = MergeChoice[null.String]{From: group[0].ID}
+	var referredAt null.Time
+	for _, u := range group {
+		if !u.ReferringUserID.Valid || slices.Contains(ids, u.ReferringUserID.String) {
+			continue
+		}
+		if !plan.Referrer.Value.Valid || u.ReferredAt.Valid && (!referredAt.Valid || u.ReferredAt.Time.Before(referredAt.Time)) {
+			plan.Referrer = MergeChoice[null.String]{Value: u.ReferringUserID, From: u.ID}
+			referredAt = u.ReferredAt
+		}
+	}
+
+	tos := group[0]
+	for _, u := range group {
+		if u.AgreedTosAt.Valid && (!tos.AgreedTosAt.Valid || u.AgreedTosAt.Time.After(tos.AgreedTosAt.Time)) {
+			tos = u
+		}
+	}
+	plan.AgreedTOSAt = MergeChoice[null.Time]{Value: tos.AgreedTosAt, From: tos.ID}
+
+	migrated := group[0]
+	for _, u := range group {
+		if u.MigratedAt.Valid && (!migrated.MigratedAt.Valid || u.MigratedAt.Time.Before(migrated.MigratedAt.Time)) {
+			migrated = u
+		}
+	}
+	plan.MigratedAt = MergeChoice[null.Time]{Value: migrated.MigratedAt, From: migrated.ID}
+
+	country := first(group, func(u *models.User) bool { return u.CountryCode.Valid })
+	plan.CountryCode = MergeChoice[null.String]{Value: country.CountryCode, From: country.ID}
+
+	return plan, nil
+}
+
+// MergeDuplicates merges the active accounts sharing addr into one. The plan is worked
+// out under lock, so it's the one carried out; with dryRun, nothing is changed. Merged
+// accounts keep their rows, pointing at the survivor, and each gets an audit record
+// naming the actor. Every account that changes gets an event.
+func MergeDuplicates(ctx context.Context, dbs db.Store, addr common.Address, actor string, dryRun bool) (*MergePlan, error) {
+	tx, err := dbs.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	group, err := duplicateGroup(ctx, tx, addr, qm.For("UPDATE"))
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := planMerge(addr, group)
+	if err != nil || dryRun {
+		return plan, err
+	}
+
+	planJSON, err := json.Marshal(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*models.User, len(group))
+	for _, u := range group {
+		byID[u.ID] = u
+	}
+	survivor := group[0]
+	email := *byID[plan.Email.From]
+
+	// Free up the referral code and confirmed email before the survivor takes them.
+	for _, u := range group[1:] {
+		snapshot, err := json.Marshal(u)
+		if err != nil {
+			return nil, err
+		}
+
+		u.MergedIntoID = null.StringFrom(survivor.ID)
+		u.ReferralCode = null.String{}
+		u.EmailConfirmed = false
+
+		if _, err := u.Update(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+
+		audit := models.UserMerge{
+			ID:              ksuid.New().String(),
+			SurvivorID:      survivor.ID,
+			MergedID:        u.ID,
+			EthereumAddress: addr.Bytes(),
+			Actor:           actor,
+			Plan:            types.JSON(planJSON),
+			MergedUser:      types.JSON(snapshot),
+		}
+		if err := audit.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+
+		if err := EnqueueUserEvent(ctx, tx, UserMergedEventType, UserEventData{
+			UserID:          u.ID,
+			EthereumAddress: addr.Hex(),
+			MergedIntoID:    survivor.ID,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	survivor.EmailAddress = email.EmailAddress
+	survivor.EmailConfirmed = email.EmailConfirmed
+	survivor.ReferralCode = plan.ReferralCode.Value
+
+	referred := byID[plan.Referrer.From]
+	if plan.Referrer.Value.Valid {
+		survivor.ReferringUserID = referred.ReferringUserID
+		survivor.ReferredAt = referred.ReferredAt
+	} else if survivor.ReferringUserID.Valid && byID[survivor.ReferringUserID.String] != nil {
+		// Referred by a duplicate of itself.
+		survivor.ReferringUserID = null.String{}
+		survivor.ReferredAt = null.Time{}
+	}
+
+	tos := byID[plan.AgreedTOSAt.From]
+	survivor.AgreedTosAt = tos.AgreedTosAt
+	survivor.AgreedTosVersion = tos.AgreedTosVersion
+
+	survivor.MigratedAt = plan.MigratedAt.Value
+	survivor.CountryCode = plan.CountryCode.Value
+
+	for _, u := range group {
+		survivor.InAppWallet = survivor.InAppWallet || u.InAppWallet
+		if u.Web3UsedAt.Valid && (!survivor.Web3UsedAt.Valid || u.Web3UsedAt.Time.Before(survivor.Web3UsedAt.Time)) {
+			survivor.Web3UsedAt = u.Web3UsedAt
+		}
+	}
+
+	if _, err := survivor.Update(ctx, tx, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	if err := EnqueueUserEvent(ctx, tx, UserUpdatedEventType, UserEventData{
+		UserID:          survivor.ID,
+		EmailAddress:    survivor.EmailAddress.String,
+		EthereumAddress: addr.Hex(),
+	}); err != nil {
+		return nil, err
+	}
+
+	merged := make([]any, len(plan.MergedIDs))
+	for i, id := range plan.MergedIDs {
+		merged[i] = id
+	}
+
+	// Anyone referred by a merged account now counts as referred by the survivor, unless
+	// they're among the survivor's own referrers. Then the referral would close a loop, so
+	// it's dropped.
+	referees, err := models.Users(
+		qm.WhereIn(models.UserColumns.ReferringUserID+" IN ?", merged...),
+		models.UserWhere.ID.NEQ(survivor.ID),
+		qm.OrderBy(models.UserColumns.ID),
+		qm.For("UPDATE"),
+	).All(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range referees {
+		cycle, err := ReferralCycle(ctx, tx, r.ID, survivor)
+		if err != nil {
+			return nil, err
+		}
+
+		if cycle {
+			r.ReferringUserID = null.String{}
+			r.ReferredAt = null.Time{}
+		} else {
+			r.ReferringUserID = null.StringFrom(survivor.ID)
+		}
+
+		if _, err := r.Update(ctx, tx, boil.Whitelist(models.UserColumns.ReferringUserID, models.UserColumns.ReferredAt)); err != nil {
+			return nil, err
+		}
+
+		// Merged accounts have had their event.
+		if slices.Contains(plan.MergedIDs, r.ID) {
+			continue
+		}
+
+		if err := EnqueueUserEvent(ctx, tx, UserUpdatedEventType, UserEventData{UserID: r.ID}); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := models.TosAgreements(
+		qm.WhereIn(models.TosAgreementColumns.UserID+" IN ?", merged...),
+	).UpdateAll(ctx, tx, models.M{models.TosAgreementColumns.UserID: survivor.ID}); err != nil {
+		return nil, err
+	}
+
+	// Logins to the merged accounts now act as the survivor.
+	if _, err := models.UserIdentities(
+		qm.WhereIn(models.UserIdentityColumns.UserID+" IN ?", merged...),
+	).UpdateAll(ctx, tx, models.M{models.UserIdentityColumns.UserID: survivor.ID}); err != nil {
+		return nil, err
+	}
+
+	// Wallets move to the survivor, as additional ones, unless it already has them. Going
+	// account by account keeps two merged accounts' copies of a wallet from colliding.
+	for _, id := range plan.MergedIDs {
+		if _, err := models.UserWallets(
+			models.UserWalletWhere.UserID.EQ(id),
+			qm.Where(`NOT EXISTS (
+				SELECT 1 FROM users_api.user_wallets survivor WHERE survivor.user_id = ? AND survivor.address = user_wallets.address
+			)`, survivor.ID),
+		).UpdateAll(ctx, tx, models.M{
+			models.UserWalletColumns.UserID:    survivor.ID,
+			models.UserWalletColumns.IsPrimary: false,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// ResolveMerged follows merged_into_id from user to the account it was merged into, if
+// any. Merges can chain, but not loop. Deleted accounts aren't followed.
+func ResolveMerged(ctx context.Context, exec boil.ContextExecutor, user *models.User, mods ...qm.QueryMod) (*models.User, error) {
+	for user.MergedIntoID.Valid {
+		next, err := FindUser(ctx, exec, user.MergedIntoID.String, mods...)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// The survivor is gone, or going, which leaves this account on its own.
+				return user, nil
+			}
+			return nil, err
+		}
+		user = next
+	}
+	return user, nil
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func (s *ServicesTestSuite) TestMergeDuplicates() {
+	ctx := context.Background()
+
+	addr := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	now := time.Now().Truncate(time.Second)
+
+	referrer := s.insertWallet("referrer", common.HexToAddress("0x0000000000000000000000000000000000000002"), true)
+
+	// The survivor: confirmed email, but the newest.
+	survivor := s.insertWallet("a", addr, true)
+	survivor.EmailAddress = null.StringFrom("a@example.com")
+	survivor.EmailConfirmed = true
+	survivor.AgreedTosAt = null.TimeFrom(now.Add(-time.Hour))
+	survivor.CreatedAt = now
+	_, err := survivor.Update(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	older := s.insertWallet("b", addr, true)
+	older.EmailAddress = null.StringFrom("b@example.com")
+	older.ReferralCode = null.StringFrom("BBBBBB")
+	older.ReferringUserID = null.StringFrom(referrer.ID)
+	older.ReferredAt = null.TimeFrom(now.Add(-2 * time.Hour))
+	older.AgreedTosAt = null.TimeFrom(now)
+	older.AgreedTosVersion = null.StringFrom("v2")
+	older.MigratedAt = null.TimeFrom(now.Add(-3 * time.Hour))
+	older.CreatedAt = now.Add(-24 * time.Hour)
+	_, err = older.Update(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	referee := s.insertWallet("referee", common.HexToAddress("0x0000000000000000000000000000000000000003"), true)
+	referee.ReferringUserID = null.StringFrom(older.ID)
+	_, err = referee.Update(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	identity := models.UserIdentity{ProviderID: "apple", Subject: older.ID, UserID: older.ID}
+	s.Require().NoError(identity.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	// Both have the shared address and another in common; the older account also has one
+	// of its own.
+	shared := common.HexToAddress("0x0000000000000000000000000000000000000004")
+	own := common.HexToAddress("0x0000000000000000000000000000000000000005")
+	for _, w := range []models.UserWallet{
+		{UserID: survivor.ID, Address: addr.Bytes(), Kind: WalletKindExternal, IsPrimary: true},
+		{UserID: survivor.ID, Address: shared.Bytes(), Kind: WalletKindExternal},
+		{UserID: older.ID, Address: addr.Bytes(), Kind: WalletKindExternal, IsPrimary: true},
+		{UserID: older.ID, Address: shared.Bytes(), Kind: WalletKindInApp},
+		{UserID: older.ID, Address: own.Bytes(), Kind: WalletKindInApp, Label: null.StringFrom("Phone")},
+	} {
+		s.Require().NoError(w.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+	}
+
+	addrs, err := DuplicateAddresses(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Equal([]common.Address{addr}, addrs)
+
+	plan, err := MergeDuplicates(ctx, s.dbs, addr, "test", true)
+	s.Require().NoError(err)
+	s.Equal("a", plan.SurvivorID)
+	s.Equal([]string{"b"}, plan.MergedIDs)
+	s.Equal(MergeChoice[null.String]{Value: null.StringFrom("a@example.com"), From: "a"}, plan.Email)
+	s.Equal("b", plan.ReferralCode.From)
+	s.Equal("b", plan.Referrer.From)
+	s.Equal("b", plan.AgreedTOSAt.From)
+	s.Equal("b", plan.MigratedAt.From)
+
+	// A dry run changes nothing.
+	s.Require().NoError(older.Reload(ctx, s.dbs.DBS().Reader))
+	s.False(older.MergedIntoID.Valid)
+	n, err := models.UserMerges().Count(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Zero(n)
+	n, err = models.Outboxes().Count(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Zero(n)
+
+	_, err = MergeDuplicates(ctx, s.dbs, addr, "test", false)
+	s.Require().NoError(err)
+
+	for _, u := range []*models.User{survivor, older, referee} {
+		s.Require().NoError(u.Reload(ctx, s.dbs.DBS().Reader))
+	}
+
+	s.Equal(null.StringFrom("a"), older.MergedIntoID)
+	s.False(older.ReferralCode.Valid)
+
+	s.Equal("a@example.com", survivor.EmailAddress.String)
+	s.True(survivor.EmailConfirmed)
+	s.Equal(null.StringFrom("BBBBBB"), survivor.ReferralCode)
+	s.Equal(null.StringFrom(referrer.ID), survivor.ReferringUserID)
+	s.Equal(null.StringFrom("v2"), survivor.AgreedTosVersion)
+	s.True(now.Equal(survivor.AgreedTosAt.Time))
+	s.True(now.Add(-3 * time.Hour).Equal(survivor.MigratedAt.Time))
+
+	s.Equal(null.StringFrom("a"), referee.ReferringUserID)
+
+	// Logins to the merged account act as the survivor.
+	userID, err := ResolveIdentity(ctx, s.dbs.DBS().Reader, "apple", older.ID)
+	s.Require().NoError(err)
+	s.Equal("a", userID)
+
+	// The older account's own wallet moves over; the ones the survivor has stay behind.
+	wallets, err := models.UserWallets(qm.OrderBy(models.UserWalletColumns.Address)).All(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Require().Len(wallets, 5)
+	owners := make(map[common.Address][]string)
+	for _, w := range wallets {
+		owners[common.BytesToAddress(w.Address)] = append(owners[common.BytesToAddress(w.Address)], w.UserID)
+		if w.UserID == "a" {
+			s.Equal(bytes.Equal(w.Address, addr.Bytes()), w.IsPrimary)
+		}
+	}
+	s.ElementsMatch([]string{"a", "b"}, owners[addr])
+	s.ElementsMatch([]string{"a", "b"}, owners[shared])
+	s.Equal([]string{"a"}, owners[own])
+
+	audit, err := models.UserMerges().All(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Require().Len(audit, 1)
+	s.Equal("a", audit[0].SurvivorID)
+	s.Equal("b", audit[0].MergedID)
+	s.Equal("test", audit[0].Actor)
+
+	// Every account that changed gets an event.
+	events, err := models.Outboxes(qm.OrderBy(models.OutboxColumns.ID)).All(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	eventTypes := make(map[string]string)
+	for _, e := range events {
+		eventTypes[e.UserID] = e.EventType
+	}
+	s.Equal(map[string]string{
+		"a":       UserUpdatedEventType,
+		"b":       UserMergedEventType,
+		"referee": UserUpdatedEventType,
+	}, eventTypes)
+
+	// The merged account now resolves to the survivor, and there's nothing left to merge.
+	resolved, err := ResolveMerged(ctx, s.dbs.DBS().Reader, older)
+	s.Require().NoError(err)
+	s.Equal("a", resolved.ID)
+
+	_, err = MergeDuplicates(ctx, s.dbs, addr, "test", false)
+	s.ErrorIs(err, ErrNoDuplicates)
+
+	// A deleted survivor isn't resolved to.
+	survivor.DeletedAt = null.TimeFrom(time.Now())
+	_, err = survivor.Update(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	resolved, err = ResolveMerged(ctx, s.dbs.DBS().Reader, older)
+	s.Require().NoError(err)
+	s.Equal("b", resolved.ID)
+}
+
+func (s *ServicesTestSuite) TestMergeDuplicates_ReferralCycle() {
+	ctx := context.Background()
+
+	addr := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	survivor := s.insertWallet("a", addr, true)
+	survivor.CreatedAt = time.Now().Add(-time.Hour)
+	_, err := survivor.Update(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	merged := s.insertWallet("b", addr, true)
+
+	// The survivor was referred by someone the merged account referred. Moving that
+	// referral to the survivor would make the two refer each other.
+	referee := s.insertWallet("referee", common.HexToAddress("0x0000000000000000000000000000000000000002"), true)
+	referee.ReferringUserID = null.StringFrom(merged.ID)
+	referee.ReferredAt = null.TimeFrom(time.Now())
+	_, err = referee.Update(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	survivor.ReferringUserID = null.StringFrom(referee.ID)
+	_, err = survivor.Update(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	plan, err := MergeDuplicates(ctx, s.dbs, addr, "test", false)
+	s.Require().NoError(err)
+	s.Equal("a", plan.SurvivorID)
+
+	s.Require().NoError(survivor.Reload(ctx, s.dbs.DBS().Reader))
+	s.Require().NoError(referee.Reload(ctx, s.dbs.DBS().Reader))
+
+	s.Equal(null.StringFrom(referee.ID), survivor.ReferringUserID)
+	s.False(referee.ReferringUserID.Valid)
+	s.False(referee.ReferredAt.Valid)
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/goccy/go-json"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// outboxLockKey is the Postgres advisory lock that ensures only one replica relays at a
+// time. Without it, two relays could publish a user's events out of order.
+const outboxLockKey = 0x75736572 // "user"
+
+// outboxHeadCondition matches rows with no earlier event for the same user.
+const outboxHeadCondition = `NOT EXISTS (
+	SELECT 1 FROM users_api.outbox earlier WHERE earlier.user_id = outbox.user_id AND earlier.id < outbox.id
+)`
+
+const (
+	defaultOutboxInterval  = time.Second
+	defaultOutboxBatchSize = 100
+	maxOutboxBackoff       = 5 * time.Minute
+)
+
+var (
+	outboxPublished = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "users_api",
+		Subsystem: "outbox",
+		Name:      "published_total",
+		Help:      "Number of outbox events published to the sink.",
+	})
+	outboxFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "users_api",
+		Subsystem: "outbox",
+		Name:      "failures_total",
+		Help:      "Number of failed attempts to publish outbox events.",
+	})
+	outboxPending = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "users_api",
+		Subsystem: "outbox",
+		Name:      "pending",
+		Help:      "Number of events waiting in the outbox.",
+	})
+	outboxLag = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "users_api",
+		Subsystem: "outbox",
+		Name:      "lag_seconds",
+		Help:      "Age of the oldest event waiting in the outbox.",
+	})
+)
+
+// EnqueueUserEvent records an event in the outbox. Pass the transaction that makes the
+// change being described, so that the event is written if and only if the change is.
+//
+// Outbox ids are assigned on insert rather than on commit, so the transaction must hold the
+// user's row lock (FindUser with qm.For("UPDATE")) before enqueuing. Otherwise two concurrent
+// changes could commit their events in the opposite order to their ids.
+func EnqueueUserEvent(ctx context.Context, exec boil.ContextExecutor, eventType string, data UserEventData) error {
+	if data.Timestamp.IsZero() {
+		data.Timestamp = time.Now()
+	}
+
+	b, err := json.Marshal(NewUserEvent(eventType, data))
+	if err != nil {
+		return err
+	}
+
+	row := models.Outbox{
+		UserID:    data.UserID,
+		EventType: eventType,
+		Payload:   b,
+	}
+
+	return row.Insert(ctx, exec, boil.Infer())
+}
+
+// OutboxRelay moves events from the outbox table to a sink. Events for a given user are
+// published in the order they were written; a failure holds back that user's later events
+// until it succeeds.
+type OutboxRelay struct {
+	dbs       db.Store
+	sink      EventProducer
+	logger    *zerolog.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+func NewOutboxRelay(dbs db.Store, sink EventProducer, logger *zerolog.Logger) *OutboxRelay {
+	return &OutboxRelay{
+		dbs:       dbs,
+		sink:      sink,
+		logger:    logger,
+		interval:  defaultOutboxInterval,
+		batchSize: defaultOutboxBatchSize,
+	}
+}
+
+// Run relays events until the context is cancelled.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := r.RelayBatch(ctx)
+			if err != nil {
+				r.logger.Err(err).Msg("Failed to relay outbox events.")
+				break
+			}
+			// Every attempt either removes a row or pushes it into the future, so this
+			// stops once nothing is due.
+			if n == 0 || ctx.Err() != nil {
+				break
+			}
+		}
+
+		if err := r.updateMetrics(ctx); err != nil {
+			r.logger.Err(err).Msg("Failed to compute outbox metrics.")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayBatch makes one pass over the outbox, trying the oldest event of each user whose
+// oldest event is due. It returns the number of events attempted. No transaction is open
+// while publishing: rows are deleted, or rescheduled, once their attempts are over, so an
+// event may be published again if we stop in between.
+func (r *OutboxRelay) RelayBatch(ctx context.Context) (int, error) {
+	// The advisory lock belongs to the session, so we keep to one connection.
+	c, err := r.dbs.DBS().Writer.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+	conn := sessionConn{c}
+
+	var lock struct {
+		Acquired bool `boil:"acquired"`
+	}
+	if err := queries.Raw("SELECT pg_try_advisory_lock($1) AS acquired", outboxLockKey).Bind(ctx, conn, &lock); err != nil {
+		return 0, err
+	}
+	if !lock.Acquired {
+		// Another replica is relaying.
+		return 0, nil
+	}
+	defer func() {
+		// The connection goes back to the pool, so the lock mustn't outlive this call, even
+		// if ctx is done.
+		if _, err := queries.Raw("SELECT pg_advisory_unlock($1)", outboxLockKey).ExecContext(context.WithoutCancel(ctx), conn); err != nil {
+			r.logger.Err(err).Msg("Failed to release the outbox lock.")
+		}
+	}()
+
+	// Only the head of each user's queue is eligible, so a user whose head is backing off
+	// holds back nobody else.
+	rows, err := models.Outboxes(
+		qm.Where(models.OutboxColumns.NextAttemptAt+" <= now()"),
+		qm.Where(outboxHeadCondition),
+		qm.OrderBy(models.OutboxColumns.ID),
+		qm.Limit(r.batchSize),
+	).All(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var sent []int64
+
+	for _, row := range rows {
+		if err := r.publish(ctx, row); err != nil {
+			outboxFailures.Inc()
+			r.logger.Err(err).Int64("outboxId", row.ID).Str("userId", row.UserID).Int("attempts", row.Attempts+1).Msg("Failed to publish outbox event.")
+
+			row.Attempts++
+			row.NextAttemptAt = now.Add(outboxBackoff(row.Attempts))
+			row.LastError = null.StringFrom(err.Error())
+			if _, err := row.Update(ctx, conn, boil.Whitelist(models.OutboxColumns.Attempts, models.OutboxColumns.NextAttemptAt, models.OutboxColumns.LastError)); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		outboxPublished.Inc()
+		sent = append(sent, row.ID)
+	}
+
+	if len(sent) != 0 {
+		if _, err := models.Outboxes(models.OutboxWhere.ID.IN(sent)).DeleteAll(ctx, conn); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(rows), nil
+}
+
+// sessionConn lets sqlboiler run queries on a single pooled connection.
+type sessionConn struct {
+	*sql.Conn
+}
+
+func (c sessionConn) Exec(query string, args ...any) (sql.Result, error) {
+	return c.ExecContext(context.Background(), query, args...)
+}
+
+func (c sessionConn) Query(query string, args ...any) (*sql.Rows, error) {
+	return c.QueryContext(context.Background(), query, args...)
+}
+
+func (c sessionConn) QueryRow(query string, args ...any) *sql.Row {
+	return c.QueryRowContext(context.Background(), query, args...)
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, row *models.Outbox) error {
+	var event UserEvent
+	if err := json.Unmarshal(row.Payload, &event); err != nil {
+		return err
+	}
+	return r.sink.Emit(ctx, &event)
+}
+
+func (r *OutboxRelay) updateMetrics(ctx context.Context) error {
+	var stats struct {
+		Pending int64     `boil:"pending"`
+		Oldest  null.Time `boil:"oldest"`
+	}
+	if err := queries.Raw("SELECT count(*) AS pending, min(created_at) AS oldest FROM users_api.outbox").Bind(ctx, r.dbs.DBS().Reader, &stats); err != nil {
+		return err
+	}
+
+	outboxPending.Set(float64(stats.Pending))
+	if stats.Oldest.Valid {
+		outboxLag.Set(time.Since(stats.Oldest.Time).Seconds())
+	} else {
+		outboxLag.Set(0)
+	}
+
+	return nil
+}
+
+// outboxBackoff doubles the wait after each failed attempt, starting at one second.
+func outboxBackoff(attempts int) time.Duration {
+	if attempts > 20 {
+		return maxOutboxBackoff
+	}
+	return min(time.Second<<(attempts-1), maxOutboxBackoff)
+}
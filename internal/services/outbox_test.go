@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// flakySink fails every event for the users in failFor, and records the rest.
+type flakySink struct {
+	InMemoryEventProducer
+	failFor map[string]bool
+}
+
+func (f *flakySink) Emit(ctx context.Context, event *UserEvent) error {
+	if f.failFor[event.Subject] {
+		return errors.New("broker unavailable")
+	}
+	return f.InMemoryEventProducer.Emit(ctx, event)
+}
+
+func (s *ServicesTestSuite) TestRelayBatch() {
+	ctx := context.Background()
+
+	s.Require().NoError(EnqueueUserEvent(ctx, s.dbs.DBS().Writer, UserEmailConfirmedEventType, UserEventData{UserID: "a"}))
+	s.Require().NoError(EnqueueUserEvent(ctx, s.dbs.DBS().Writer, UserEmailConfirmedEventType, UserEventData{UserID: "b"}))
+	s.Require().NoError(EnqueueUserEvent(ctx, s.dbs.DBS().Writer, UserDeletedEventType, UserEventData{UserID: "a"}))
+	s.Require().NoError(EnqueueUserEvent(ctx, s.dbs.DBS().Writer, UserDeletedEventType, UserEventData{UserID: "b"}))
+
+	sink := &flakySink{failFor: map[string]bool{"a": true}}
+	relay := NewOutboxRelay(s.dbs, sink, s.logger)
+
+	// Each pass tries the oldest event of each user.
+	n, err := relay.RelayBatch(ctx)
+	s.Require().NoError(err)
+	s.Equal(2, n)
+
+	n, err = relay.RelayBatch(ctx)
+	s.Require().NoError(err)
+	s.Equal(1, n)
+
+	events := sink.Events()
+	s.Require().Len(events, 2)
+	s.Equal("b", events[0].Subject)
+	s.Equal(UserEmailConfirmedEventType, events[0].Type)
+	s.Equal(UserDeletedEventType, events[1].Type)
+
+	// Both of a's events stay behind, and only the first was attempted.
+	rows, err := models.Outboxes(qm.OrderBy(models.OutboxColumns.ID)).All(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Require().Len(rows, 2)
+	s.Equal(1, rows[0].Attempts)
+	s.True(rows[0].LastError.Valid)
+	s.True(rows[0].NextAttemptAt.After(time.Now()))
+	s.Equal(0, rows[1].Attempts)
+
+	// Once the broker recovers, a's events go out in order after the backoff.
+	delete(sink.failFor, "a")
+
+	n, err = relay.RelayBatch(ctx)
+	s.Require().NoError(err)
+	s.Zero(n)
+	s.Len(sink.Events(), 2)
+
+	_, err = models.Outboxes().UpdateAll(ctx, s.dbs.DBS().Writer, models.M{models.OutboxColumns.NextAttemptAt: time.Now().Add(-time.Second)})
+	s.Require().NoError(err)
+
+	for i := 0; i < 2; i++ {
+		n, err = relay.RelayBatch(ctx)
+		s.Require().NoError(err)
+		s.Equal(1, n)
+	}
+
+	events = sink.Events()
+	s.Require().Len(events, 4)
+	s.Equal("a", events[2].Subject)
+	s.Equal(UserEmailConfirmedEventType, events[2].Type)
+	s.Equal(UserDeletedEventType, events[3].Type)
+
+	count, err := models.Outboxes().Count(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Zero(count)
+}
+
+func (s *ServicesTestSuite) TestRelayBatch_Blocked() {
+	ctx := context.Background()
+
+	sink := &flakySink{failFor: map[string]bool{"a": true}}
+	relay := NewOutboxRelay(s.dbs, sink, s.logger)
+	relay.batchSize = 3
+
+	for i := 0; i < 2*relay.batchSize; i++ {
+		s.Require().NoError(EnqueueUserEvent(ctx, s.dbs.DBS().Writer, UserEmailConfirmedEventType, UserEventData{UserID: "a"}))
+	}
+	s.Require().NoError(EnqueueUserEvent(ctx, s.dbs.DBS().Writer, UserEmailConfirmedEventType, UserEventData{UserID: "b"}))
+
+	n, err := relay.RelayBatch(ctx)
+	s.Require().NoError(err)
+	s.Equal(2, n)
+
+	// With a's head backing off, nothing is due, and a's queue doesn't hold back anyone
+	// else's events.
+	n, err = relay.RelayBatch(ctx)
+	s.Require().NoError(err)
+	s.Zero(n)
+
+	s.Require().NoError(EnqueueUserEvent(ctx, s.dbs.DBS().Writer, UserDeletedEventType, UserEventData{UserID: "b"}))
+
+	n, err = relay.RelayBatch(ctx)
+	s.Require().NoError(err)
+	s.Equal(1, n)
+
+	events := sink.Events()
+	s.Require().Len(events, 2)
+	s.Equal("b", events[0].Subject)
+	s.Equal("b", events[1].Subject)
+
+	count, err := models.Outboxes(models.OutboxWhere.UserID.EQ("a")).Count(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.EqualValues(2*relay.batchSize, count)
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// bucketSweepInterval is how often the in-memory store forgets buckets that have refilled.
+const bucketSweepInterval = time.Minute
+
+var (
+	rateLimitRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "users_api",
+		Subsystem: "rate_limit",
+		Name:      "rejections_total",
+		Help:      "Requests turned away because a rate limit was exhausted.",
+	}, []string{"limiter"})
+	rateLimitErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "users_api",
+		Subsystem: "rate_limit",
+		Name:      "store_errors_total",
+		Help:      "Rate limit checks that failed because the store was unavailable.",
+	}, []string{"limiter"})
+)
+
+// RateLimit describes a token bucket: it holds at most Burst tokens and regains Rate
+// tokens per second.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute builds a limit allowing n requests a minute on average and bursts of burst.
+func PerMinute(n, burst int) RateLimit {
+	return RateLimit{Rate: float64(n) / 60, Burst: burst}
+}
+
+// PerHour builds a limit allowing n requests an hour on average and bursts of burst.
+func PerHour(n, burst int) RateLimit {
+	return RateLimit{Rate: float64(n) / 3600, Burst: burst}
+}
+
+// TokenBucketStore keeps token buckets by key. The in-memory store only limits a single
+// replica; implement this over a shared store to enforce limits across all of them.
+type TokenBucketStore interface {
+	// Take removes a token from the bucket for key, creating a full bucket if there is
+	// none. If the bucket is empty, it returns false and how long until a token is due.
+	Take(ctx context.Context, key string, limit RateLimit) (ok bool, retryAfter time.Duration, err error)
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	limit   RateLimit
+}
+
+// InMemoryTokenBucketStore is a TokenBucketStore local to the process.
+type InMemoryTokenBucketStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewInMemoryTokenBucketStore() *InMemoryTokenBucketStore {
+	return &InMemoryTokenBucketStore{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+func (s *InMemoryTokenBucketStore) Take(_ context.Context, key string, limit RateLimit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	} else {
+		b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+		b.updated = now
+	}
+	b.limit = limit
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+
+	wait := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+	return false, wait, nil
+}
+
+// sweep drops buckets that would be full by now, since a new bucket is the same thing.
+func (s *InMemoryTokenBucketStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < bucketSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*b.limit.Rate >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// RateLimiter applies one limit to many keys. The name distinguishes it in metrics.
+type RateLimiter struct {
+	store      TokenBucketStore
+	limit      RateLimit
+	rejections prometheus.Counter
+	errors     prometheus.Counter
+}
+
+func NewRateLimiter(name string, store TokenBucketStore, limit RateLimit) *RateLimiter {
+	return &RateLimiter{
+		store:      store,
+		limit:      limit,
+		rejections: rateLimitRejections.WithLabelValues(name),
+		errors:     rateLimitErrors.WithLabelValues(name),
+	}
+}
+
+// Allow reports whether a request for key may proceed, and if not, how long the caller
+// should wait. If the store fails, the request is allowed and the error returned.
+func (l *RateLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	ok, retryAfter, err := l.store.Take(ctx, key, l.limit)
+	if err != nil {
+		l.errors.Inc()
+		return true, 0, err
+	}
+
+	if !ok {
+		l.rejections.Inc()
+	}
+
+	return ok, retryAfter, nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryTokenBucketStore(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now()
+	s := NewInMemoryTokenBucketStore()
+	s.now = func() time.Time { return now }
+
+	limit := RateLimit{Rate: 1, Burst: 2}
+
+	for range 2 {
+		ok, _, err := s.Take(ctx, "a", limit)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	ok, retryAfter, err := s.Take(ctx, "a", limit)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// Other keys have their own buckets.
+	ok, _, _ = s.Take(ctx, "b", limit)
+	assert.True(t, ok)
+
+	now = now.Add(500 * time.Millisecond)
+	ok, retryAfter, _ = s.Take(ctx, "a", limit)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	now = now.Add(500 * time.Millisecond)
+	ok, _, _ = s.Take(ctx, "a", limit)
+	assert.True(t, ok)
+
+	// Buckets that have refilled are forgotten.
+	now = now.Add(time.Hour)
+	_, _, _ = s.Take(ctx, "c", limit)
+	assert.Len(t, s.buckets, 1)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// ReferralCycle reports whether userID already appears among the referrers of referrer, in
+// which case linking userID to referrer would close a loop in the referral graph.
+func ReferralCycle(ctx context.Context, exec boil.ContextExecutor, userID string, referrer *models.User) (bool, error) {
+	seen := map[string]bool{referrer.ID: true}
+
+	for cur := referrer; cur.ReferringUserID.Valid; {
+		next := cur.ReferringUserID.String
+		if next == userID {
+			return true, nil
+		}
+		if seen[next] {
+			// There's already a loop that doesn't involve us.
+			return false, nil
+		}
+		seen[next] = true
+
+		var err error
+		cur, err = models.FindUser(ctx, exec, next, models.UserColumns.ID, models.UserColumns.ReferringUserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/users-api/internal/database"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/docker/go-connections/nat"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type ServicesTestSuite struct {
+	suite.Suite
+	dbcont testcontainers.Container
+	dbs    db.Store
+	logger *zerolog.Logger
+}
+
+func TestServicesSuite(t *testing.T) {
+	suite.Run(t, &ServicesTestSuite{})
+}
+
+func (s *ServicesTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	logger := zerolog.Nop()
+	s.logger = &logger
+
+	port := "5432/tcp"
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:16.6-alpine",
+		ExposedPorts: []string{port},
+		AutoRemove:   true,
+		Env: map[string]string{
+			"POSTGRES_DB":       "users_api",
+			"POSTGRES_PASSWORD": "postgres",
+		},
+		WaitingFor: wait.ForListeningPort(nat.Port(port)),
+	}
+	dbcont, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	s.Require().NoError(err)
+	s.dbcont = dbcont
+
+	host, err := dbcont.Host(ctx)
+	s.Require().NoError(err)
+
+	mport, err := dbcont.MappedPort(ctx, nat.Port(port))
+	s.Require().NoError(err)
+
+	dbset := db.Settings{
+		User:               "postgres",
+		Password:           "postgres",
+		Port:               mport.Port(),
+		Host:               host,
+		Name:               "users_api",
+		MaxOpenConnections: 10,
+		MaxIdleConnections: 10,
+	}
+
+	err = database.MigrateDatabase(ctx, logger, &dbset, "", "../../migrations")
+	s.Require().NoError(err)
+
+	s.dbs = db.NewDbConnectionFromSettings(ctx, &dbset, true)
+	s.dbs.WaitForDB(logger)
+}
+
+func (s *ServicesTestSuite) TearDownSuite() {
+	s.Require().NoError(s.dbcont.Terminate(context.Background()))
+}
+
+func (s *ServicesTestSuite) TearDownTest() {
+	ctx := context.Background()
+
+	_, err := models.Outboxes().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+
+	_, err = models.Users().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+
+	_, err = models.IndexerCheckpoints().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+
+	_, err = models.UserMerges().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	defaultUsageCacheSize = 10_000
+	defaultUsedTTL        = 24 * time.Hour
+	defaultUnusedTTL      = 5 * time.Minute
+)
+
+var (
+	usageCacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "users_api",
+		Subsystem: "wallet_usage_cache",
+		Name:      "hits_total",
+		Help:      "Number of wallet usage lookups answered from the cache.",
+	}, []string{"cache"})
+	usageCacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "users_api",
+		Subsystem: "wallet_usage_cache",
+		Name:      "misses_total",
+		Help:      "Number of wallet usage lookups that had to go to the source.",
+	}, []string{"cache"})
+)
+
+type usageEntry struct {
+	used      bool
+	expiresAt time.Time
+}
+
+// WalletUsageCache remembers whether a wallet has been used. A used wallet stays used, so
+// positive results are kept longer than negative ones. A nil cache never hits.
+type WalletUsageCache struct {
+	entries   *lru.Cache[common.Address, usageEntry]
+	usedTTL   time.Duration
+	unusedTTL time.Duration
+	hits      prometheus.Counter
+	misses    prometheus.Counter
+}
+
+// NewWalletUsageCache creates a cache holding at most size addresses. The name
+// distinguishes this cache in metrics. Non-positive arguments get defaults.
+func NewWalletUsageCache(name string, size int, usedTTL, unusedTTL time.Duration) *WalletUsageCache {
+	if size <= 0 {
+		size = defaultUsageCacheSize
+	}
+	if usedTTL <= 0 {
+		usedTTL = defaultUsedTTL
+	}
+	if unusedTTL <= 0 {
+		unusedTTL = defaultUnusedTTL
+	}
+
+	return &WalletUsageCache{
+		entries:   lru.NewCache[common.Address, usageEntry](size),
+		usedTTL:   usedTTL,
+		unusedTTL: unusedTTL,
+		hits:      usageCacheHits.WithLabelValues(name),
+		misses:    usageCacheMisses.WithLabelValues(name),
+	}
+}
+
+// Get returns the cached usage of the address, if there is an unexpired entry.
+func (c *WalletUsageCache) Get(addr common.Address) (used, ok bool) {
+	if c == nil {
+		return false, false
+	}
+
+	e, ok := c.entries.Get(addr)
+	if !ok || time.Now().After(e.expiresAt) {
+		c.misses.Inc()
+		return false, false
+	}
+
+	c.hits.Inc()
+	return e.used, true
+}
+
+// Set records the usage of the address.
+func (c *WalletUsageCache) Set(addr common.Address, used bool) {
+	if c == nil {
+		return
+	}
+
+	ttl := c.unusedTTL
+	if used {
+		ttl = c.usedTTL
+	}
+
+	c.entries.Add(addr, usageEntry{used: used, expiresAt: time.Now().Add(ttl)})
+}
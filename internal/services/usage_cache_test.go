@@ -0,0 +1,43 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalletUsageCache(t *testing.T) {
+	c := NewWalletUsageCache("test", 2, time.Hour, time.Nanosecond)
+
+	used := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	unused := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	other := common.HexToAddress("0x0000000000000000000000000000000000000003")
+
+	_, ok := c.Get(used)
+	assert.False(t, ok)
+
+	c.Set(used, true)
+	c.Set(unused, false)
+
+	u, ok := c.Get(used)
+	assert.True(t, ok)
+	assert.True(t, u)
+
+	// Negative results expire much sooner.
+	time.Sleep(time.Millisecond)
+	_, ok = c.Get(unused)
+	assert.False(t, ok)
+
+	// The least recently used entry is evicted once the cache is full.
+	c.Set(other, true)
+	c.Set(unused, true)
+	_, ok = c.Get(used)
+	assert.False(t, ok)
+
+	var nilCache *WalletUsageCache
+	nilCache.Set(used, true)
+	_, ok = nilCache.Get(used)
+	assert.False(t, ok)
+}
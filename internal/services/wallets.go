@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Wallet kinds, for user_wallets.kind.
+const (
+	WalletKindExternal = "external"
+	WalletKindInApp    = "in_app"
+)
+
+// confirmedWalletQuery matches users with a confirmed wallet at one of the given addresses.
+const confirmedWalletQuery = `users.id IN (SELECT user_id FROM users_api.user_wallets WHERE address IN ? AND confirmed_at IS NOT NULL)`
+
+// ConfirmedAddressIn matches users with a confirmed primary address or wallet among addrs,
+// which are byte slices. The primary wallet is also in users, and users confirmed before
+// there were wallets may only be there.
+func ConfirmedAddressIn(addrs ...any) qm.QueryMod {
+	return qm.Expr(
+		qm.Expr(
+			models.UserWhere.EthereumConfirmed.EQ(true),
+			qm.WhereIn(models.UserColumns.EthereumAddress+" IN ?", addrs...),
+		),
+		qm.OrIn(confirmedWalletQuery, addrs...),
+	)
+}
+
+// ConfirmWallet records that the user has proven ownership of addr, adding the wallet with
+// the given kind if the user hasn't already added it.
+func ConfirmWallet(ctx context.Context, exec boil.ContextExecutor, userID string, addr common.Address, kind string) (*models.UserWallet, error) {
+	wallet := &models.UserWallet{
+		UserID:      userID,
+		Address:     addr.Bytes(),
+		Kind:        kind,
+		ConfirmedAt: null.TimeFrom(time.Now()),
+	}
+
+	if err := wallet.Upsert(ctx, exec, true,
+		[]string{models.UserWalletColumns.UserID, models.UserWalletColumns.Address},
+		boil.Whitelist(models.UserWalletColumns.ConfirmedAt),
+		boil.Infer(),
+	); err != nil {
+		return nil, err
+	}
+
+	// The upsert only hands back the key, and the row may have been there already.
+	if err := wallet.Reload(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
+
+// SetPrimaryWallet makes wallet the user's primary wallet, and copies it into the user's
+// ethereum_address, ethereum_confirmed and in_app_wallet columns, which older clients
+// still read. Only those columns of the user are saved.
+func SetPrimaryWallet(ctx context.Context, exec boil.ContextExecutor, user *models.User, wallet *models.UserWallet) error {
+	if _, err := models.UserWallets(
+		models.UserWalletWhere.UserID.EQ(user.ID),
+		models.UserWalletWhere.IsPrimary.EQ(true),
+	).UpdateAll(ctx, exec, models.M{models.UserWalletColumns.IsPrimary: false}); err != nil {
+		return err
+	}
+
+	wallet.IsPrimary = true
+	if _, err := wallet.Update(ctx, exec, boil.Whitelist(models.UserWalletColumns.IsPrimary)); err != nil {
+		return err
+	}
+
+	user.EthereumAddress = null.BytesFrom(wallet.Address)
+	user.EthereumConfirmed = wallet.ConfirmedAt.Valid
+	user.InAppWallet = wallet.Kind == WalletKindInApp
+
+	_, err := user.Update(ctx, exec, boil.Whitelist(
+		models.UserColumns.EthereumAddress,
+		models.UserColumns.EthereumConfirmed,
+		models.UserColumns.InAppWallet,
+	))
+	return err
+}
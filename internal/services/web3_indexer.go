@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/users-api/internal/config"
+	"github.com/DIMO-Network/users-api/internal/contracts"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+const (
+	// web3UsageIndexerName is the indexer's row in indexer_checkpoints.
+	web3UsageIndexerName = "web3_usage"
+
+	// web3UsageBlockRange is the most blocks we ask for in one log query. Providers
+	// commonly cap this somewhere in the thousands.
+	web3UsageBlockRange = 2000
+	// web3UsageInterval is how long we wait between polls, or before resubscribing.
+	web3UsageInterval = 15 * time.Second
+)
+
+// advanceCheckpointQuery never moves a checkpoint backwards, should two instances catch up
+// at once.
+const advanceCheckpointQuery = `INSERT INTO users_api.indexer_checkpoints (name, next_block) VALUES ($1, $2)
+ON CONFLICT (name) DO UPDATE SET next_block = GREATEST(indexer_checkpoints.next_block, EXCLUDED.next_block), updated_at = now()`
+
+var (
+	web3UsageNextBlock = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "users_api",
+		Name:      "web3_usage_indexer_next_block",
+		Help:      "First block the web3 usage indexer has not fully processed.",
+	})
+	web3UsageStamped = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "users_api",
+		Name:      "web3_usage_indexer_stamped_total",
+		Help:      "Users marked as having used web3 by the indexer.",
+	})
+)
+
+// IndexerBackend is the part of an Ethereum client that Web3UsageIndexer uses. Like
+// ChainBackend, it's satisfied by *ethclient.Client and by the client of go-ethereum's
+// simulated backend.
+type IndexerBackend interface {
+	bind.ContractFilterer
+	ethereum.BlockNumberReader
+	CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// Web3UsageIndexer sets web3_used_at for users whose confirmed address receives a vehicle
+// or aftermarket device NFT. It catches up from its checkpoint with log queries and then,
+// if the node supports subscriptions, watches for new transfers. Otherwise it polls. Only
+// catching up moves the checkpoint: the subscriptions deliver events independently, so a
+// watched event doesn't mean that everything before it has been seen.
+type Web3UsageIndexer struct {
+	dbs        db.Store
+	backend    IndexerBackend
+	nftAddrs   []common.Address
+	nfts       []*contracts.MultiPrivilegeFilterer
+	startBlock uint64
+	blockRange uint64
+	interval   time.Duration
+	logger     *zerolog.Logger
+
+	caughtUp atomic.Bool
+}
+
+func NewWeb3UsageIndexer(dbs db.Store, backend IndexerBackend, settings *config.Settings, logger *zerolog.Logger) (*Web3UsageIndexer, error) {
+	addrs := []common.Address{common.HexToAddress(settings.VehicleNFTAddr), common.HexToAddress(settings.ADNFTAddr)}
+
+	nfts := make([]*contracts.MultiPrivilegeFilterer, len(addrs))
+	for i, addr := range addrs {
+		nft, err := contracts.NewMultiPrivilegeFilterer(addr, backend)
+		if err != nil {
+			return nil, err
+		}
+		nfts[i] = nft
+	}
+
+	return &Web3UsageIndexer{
+		dbs:        dbs,
+		backend:    backend,
+		nftAddrs:   addrs,
+		nfts:       nfts,
+		startBlock: uint64(settings.Web3IndexerStartBlock),
+		blockRange: web3UsageBlockRange,
+		interval:   web3UsageInterval,
+		logger:     logger,
+	}, nil
+}
+
+// Run indexes until the context is cancelled.
+func (ix *Web3UsageIndexer) Run(ctx context.Context) {
+	for {
+		if err := ix.run(ctx); err != nil && ctx.Err() == nil {
+			ix.logger.Err(err).Msg("Web3 usage indexer failed.")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(ix.interval):
+		}
+	}
+}
+
+func (ix *Web3UsageIndexer) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Subscribe before catching up, so that nothing falls in between. Seeing an event twice
+	// is harmless.
+	sink := make(chan *contracts.MultiPrivilegeTransfer)
+	errc := make(chan error, len(ix.nfts))
+
+	for _, nft := range ix.nfts {
+		sub, err := nft.WatchTransfer(&bind.WatchOpts{Context: ctx}, sink, nil, nil, nil)
+		if err != nil {
+			if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+				// Plain HTTP. Run will call us again after the interval.
+				_, err := ix.CatchUp(ctx)
+				return err
+			}
+			return err
+		}
+		defer sub.Unsubscribe()
+
+		go func() {
+			if err := <-sub.Err(); err != nil {
+				errc <- err
+			}
+		}()
+	}
+
+	if _, err := ix.CatchUp(ctx); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(ix.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errc:
+			return err
+		case ev := <-sink:
+			if _, err := StampWeb3Used(ctx, ix.dbs.DBS().Writer, recipients([]*contracts.MultiPrivilegeTransfer{ev})...); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			// Watched events were stamped as they came, so this mostly just moves the
+			// checkpoint.
+			if _, err := ix.CatchUp(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// CatchUp processes every block from the checkpoint through the current head, and returns
+// the new checkpoint.
+func (ix *Web3UsageIndexer) CatchUp(ctx context.Context) (uint64, error) {
+	from, err := ix.nextBlock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	head, err := ix.backend.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for from <= head {
+		to := min(from+ix.blockRange-1, head)
+
+		var events []*contracts.MultiPrivilegeTransfer
+
+		for _, nft := range ix.nfts {
+			it, err := nft.FilterTransfer(&bind.FilterOpts{Start: from, End: &to, Context: ctx}, nil, nil, nil)
+			if err != nil {
+				return 0, err
+			}
+			for it.Next() {
+				events = append(events, it.Event)
+			}
+			err = it.Error()
+			it.Close()
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		if err := ix.record(ctx, events, to+1); err != nil {
+			return 0, err
+		}
+
+		from = to + 1
+	}
+
+	ix.caughtUp.Store(true)
+
+	return from, nil
+}
+
+// CaughtUp reports whether the indexer has processed the chain up to a recent head since
+// the process started. Until it has, web3_used_at may be missing for past transfers.
+func (ix *Web3UsageIndexer) CaughtUp() bool {
+	return ix.caughtUp.Load()
+}
+
+func (ix *Web3UsageIndexer) nextBlock(ctx context.Context) (uint64, error) {
+	cp, err := models.FindIndexerCheckpoint(ctx, ix.dbs.DBS().Reader, web3UsageIndexerName)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, err
+		}
+		if ix.startBlock != 0 {
+			return ix.startBlock, nil
+		}
+
+		block, err := ix.deploymentBlock(ctx)
+		if err != nil {
+			// Historical state may need an archive node. Scanning everything is slow, but
+			// complete.
+			ix.logger.Warn().Err(err).Msg("Couldn't find the NFT deployment block, starting from genesis.")
+			return 0, nil
+		}
+		return block, nil
+	}
+	return uint64(cp.NextBlock), nil
+}
+
+// deploymentBlock finds the first block in which one of the NFT contracts has code, by
+// bisection.
+func (ix *Web3UsageIndexer) deploymentBlock(ctx context.Context) (uint64, error) {
+	head, err := ix.backend.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	first := head
+	for _, addr := range ix.nftAddrs {
+		code, err := ix.backend.CodeAt(ctx, addr, new(big.Int).SetUint64(head))
+		if err != nil {
+			return 0, err
+		}
+		if len(code) == 0 {
+			return 0, fmt.Errorf("no contract at %s", addr.Hex())
+		}
+
+		// The contract has code at hi, but not before lo.
+		lo, hi := uint64(0), head
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			code, err := ix.backend.CodeAt(ctx, addr, new(big.Int).SetUint64(mid))
+			if err != nil {
+				return 0, err
+			}
+			if len(code) == 0 {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+
+		first = min(first, hi)
+	}
+
+	return first, nil
+}
+
+// recipients returns the addresses that events transferred NFTs to.
+func recipients(events []*contracts.MultiPrivilegeTransfer) []common.Address {
+	var out []common.Address
+	for _, ev := range events {
+		// Logs removed by a reorg don't undo anything: the address was still used.
+		if !ev.Raw.Removed {
+			out = append(out, ev.To)
+		}
+	}
+	return out
+}
+
+// record stamps the recipients of events and advances the checkpoint to next, atomically.
+func (ix *Web3UsageIndexer) record(ctx context.Context, events []*contracts.MultiPrivilegeTransfer, next uint64) error {
+	tx, err := ix.dbs.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := StampWeb3Used(ctx, tx, recipients(events)...); err != nil {
+		return err
+	}
+
+	if _, err := queries.Raw(advanceCheckpointQuery, web3UsageIndexerName, int64(next)).ExecContext(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	web3UsageNextBlock.Set(float64(next))
+
+	return nil
+}
+
+// StampWeb3Used sets web3_used_at for users with a confirmed address or wallet among
+// addrs, if it isn't already set. It returns the number of users stamped.
+func StampWeb3Used(ctx context.Context, exec boil.ContextExecutor, addrs ...common.Address) (int64, error) {
+	seen := make(map[common.Address]bool, len(addrs))
+	args := make([]any, 0, len(addrs))
+
+	for _, addr := range addrs {
+		// Burns go to the zero address.
+		if addr == (common.Address{}) || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		args = append(args, addr.Bytes())
+	}
+
+	if len(args) == 0 {
+		return 0, nil
+	}
+
+	n, err := models.Users(
+		models.UserWhere.Web3UsedAt.IsNull(),
+		ConfirmedAddressIn(args...),
+	).UpdateAll(ctx, exec, models.M{models.UserColumns.Web3UsedAt: time.Now()})
+	if err != nil {
+		return 0, err
+	}
+
+	web3UsageStamped.Add(float64(n))
+
+	return n, nil
+}
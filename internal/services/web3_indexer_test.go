@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/users-api/internal/config"
+	"github.com/DIMO-Network/users-api/internal/contracts/contractstest"
+	"github.com/DIMO-Network/users-api/models"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// laggingBackend wraps the client of a simulated chain. It counts log queries and, while
+// head is set, reports that as the latest block, like a node that's behind.
+type laggingBackend struct {
+	IndexerBackend
+
+	mu      sync.Mutex
+	queries int
+	head    *uint64
+}
+
+func (b *laggingBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	b.mu.Lock()
+	head := b.head
+	b.mu.Unlock()
+
+	if head != nil {
+		return *head, nil
+	}
+	return b.IndexerBackend.BlockNumber(ctx)
+}
+
+func (b *laggingBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	b.mu.Lock()
+	b.queries++
+	b.mu.Unlock()
+
+	return b.IndexerBackend.FilterLogs(ctx, q)
+}
+
+func (b *laggingBackend) setHead(head *uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.head = head
+}
+
+func indexerSettings(chain *contractstest.Chain, startBlock uint64) *config.Settings {
+	return &config.Settings{
+		VehicleNFTAddr:        chain.VehicleNFT.Hex(),
+		ADNFTAddr:             chain.AftermarketDevice.Hex(),
+		Web3IndexerStartBlock: int64(startBlock),
+	}
+}
+
+func (s *ServicesTestSuite) insertWallet(id string, addr common.Address, confirmed bool) *models.User {
+	u := &models.User{
+		ID:                id,
+		EthereumAddress:   null.BytesFrom(addr.Bytes()),
+		EthereumConfirmed: confirmed,
+		CreatedAt:         time.Now(),
+	}
+	s.Require().NoError(u.Insert(context.Background(), s.dbs.DBS().Writer, boil.Infer()))
+	return u
+}
+
+func (s *ServicesTestSuite) TestWeb3UsageIndexer_CatchUp() {
+	ctx := context.Background()
+
+	vehicleOwner := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	adOwner := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	unconfirmed := common.HexToAddress("0x0000000000000000000000000000000000000003")
+	early := common.HexToAddress("0x0000000000000000000000000000000000000004")
+
+	u1 := s.insertWallet("u1", vehicleOwner, true)
+	u2 := s.insertWallet("u2", adOwner, true)
+	u3 := s.insertWallet("u3", unconfirmed, false)
+	u4 := s.insertWallet("u4", early, true)
+
+	chain := contractstest.NewChain(s.T())
+
+	// Before the start block.
+	start := chain.MintNFT(chain.VehicleNFT, early) + 1
+
+	chain.MintNFT(chain.VehicleNFT, vehicleOwner)
+	chain.MintNFT(chain.AftermarketDevice, adOwner)
+	head := chain.MintNFT(chain.VehicleNFT, unconfirmed)
+
+	backend := &laggingBackend{IndexerBackend: chain.Backend.Client()}
+
+	ix, err := NewWeb3UsageIndexer(s.dbs, backend, indexerSettings(chain, start), s.logger)
+	s.Require().NoError(err)
+	ix.blockRange = 2
+
+	next, err := ix.CatchUp(ctx)
+	s.Require().NoError(err)
+	s.Equal(head+1, next)
+	// Two ranges, two contracts each.
+	s.Equal(4, backend.queries)
+
+	for _, u := range []*models.User{u1, u2, u3, u4} {
+		s.Require().NoError(u.Reload(ctx, s.dbs.DBS().Reader))
+	}
+	s.True(u1.Web3UsedAt.Valid)
+	s.True(u2.Web3UsedAt.Valid)
+	s.False(u3.Web3UsedAt.Valid)
+	s.False(u4.Web3UsedAt.Valid)
+
+	cp, err := models.FindIndexerCheckpoint(ctx, s.dbs.DBS().Reader, web3UsageIndexerName)
+	s.Require().NoError(err)
+	s.EqualValues(head+1, cp.NextBlock)
+
+	// Nothing new, so nothing to query.
+	next, err = ix.CatchUp(ctx)
+	s.Require().NoError(err)
+	s.Equal(head+1, next)
+	s.Equal(4, backend.queries)
+
+	// A later transfer doesn't move an existing stamp.
+	stamped := u1.Web3UsedAt.Time
+	chain.TransferNFT(chain.AftermarketDevice, adOwner, vehicleOwner, 1)
+
+	_, err = ix.CatchUp(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(u1.Reload(ctx, s.dbs.DBS().Reader))
+	s.True(stamped.Equal(u1.Web3UsedAt.Time))
+}
+
+func (s *ServicesTestSuite) TestWeb3UsageIndexer_DeploymentBlock() {
+	ctx := context.Background()
+
+	chain := contractstest.NewChain(s.T())
+	for range 20 {
+		chain.Backend.Commit()
+	}
+
+	// The aftermarket device NFT is deployed first here.
+	settings := indexerSettings(chain, 0)
+	settings.VehicleNFTAddr = chain.Multicall.Hex()
+
+	ix, err := NewWeb3UsageIndexer(s.dbs, chain.Backend.Client(), settings, s.logger)
+	s.Require().NoError(err)
+
+	// Without a checkpoint or a configured start, we start where the first NFT was deployed.
+	next, err := ix.nextBlock(ctx)
+	s.Require().NoError(err)
+	s.Equal(chain.DeployBlock(chain.AftermarketDevice), next)
+
+	// Not deployed at all, so we fall back to scanning everything.
+	settings.ADNFTAddr = "0x0000000000000000000000000000000000000001"
+
+	ix, err = NewWeb3UsageIndexer(s.dbs, chain.Backend.Client(), settings, s.logger)
+	s.Require().NoError(err)
+
+	next, err = ix.nextBlock(ctx)
+	s.Require().NoError(err)
+	s.Zero(next)
+}
+
+func (s *ServicesTestSuite) TestStampWeb3Used_Wallets() {
+	ctx := context.Background()
+
+	addr := common.HexToAddress("0x0000000000000000000000000000000000000005")
+	pending := common.HexToAddress("0x0000000000000000000000000000000000000006")
+
+	owner := s.insertWallet("owner", common.HexToAddress("0x0000000000000000000000000000000000000007"), true)
+	_, err := ConfirmWallet(ctx, s.dbs.DBS().Writer, owner.ID, addr, WalletKindExternal)
+	s.Require().NoError(err)
+
+	unconfirmed := s.insertWallet("unconfirmed", common.HexToAddress("0x0000000000000000000000000000000000000008"), true)
+	w := &models.UserWallet{UserID: unconfirmed.ID, Address: pending.Bytes(), Kind: WalletKindExternal}
+	s.Require().NoError(w.Insert(ctx, s.dbs.DBS().Writer, boil.Infer()))
+
+	n, err := StampWeb3Used(ctx, s.dbs.DBS().Writer, addr, pending)
+	s.Require().NoError(err)
+	s.EqualValues(1, n)
+
+	s.Require().NoError(owner.Reload(ctx, s.dbs.DBS().Reader))
+	s.True(owner.Web3UsedAt.Valid)
+	s.Require().NoError(unconfirmed.Reload(ctx, s.dbs.DBS().Reader))
+	s.False(unconfirmed.Web3UsedAt.Valid)
+}
+
+func (s *ServicesTestSuite) TestWeb3UsageIndexer_Watch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	addr := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	u := s.insertWallet("u1", addr, true)
+
+	chain := contractstest.NewChain(s.T())
+	head := chain.Head()
+	start := head + 1
+
+	// Catching up won't see anything we mint until we let it.
+	backend := &laggingBackend{IndexerBackend: chain.Backend.Client(), head: &head}
+
+	ix, err := NewWeb3UsageIndexer(s.dbs, backend, indexerSettings(chain, start), s.logger)
+	s.Require().NoError(err)
+	ix.interval = 50 * time.Millisecond
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ix.Run(ctx)
+	}()
+
+	// Wait for the catch-up to finish. We subscribe before catching up.
+	s.Eventually(func() bool {
+		cp, err := models.FindIndexerCheckpoint(ctx, s.dbs.DBS().Reader, web3UsageIndexerName)
+		return err == nil && cp.NextBlock == int64(start)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	block := chain.MintNFT(chain.AftermarketDevice, addr)
+
+	s.Eventually(func() bool {
+		err := u.Reload(ctx, s.dbs.DBS().Reader)
+		return err == nil && u.Web3UsedAt.Valid
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A watched event doesn't move the checkpoint: the other contract's events from
+	// earlier blocks may still be on their way.
+	cp, err := models.FindIndexerCheckpoint(ctx, s.dbs.DBS().Reader, web3UsageIndexerName)
+	s.Require().NoError(err)
+	s.EqualValues(start, cp.NextBlock)
+
+	// A catch-up that reaches the block does.
+	backend.setHead(nil)
+
+	s.Eventually(func() bool {
+		cp, err := models.FindIndexerCheckpoint(ctx, s.dbs.DBS().Reader, web3UsageIndexerName)
+		return err == nil && cp.NextBlock == int64(block+1)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+}
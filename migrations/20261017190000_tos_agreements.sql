@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+ALTER TABLE users ADD COLUMN agreed_tos_version text;
+
+CREATE TABLE tos_agreements (
+    id char(27) PRIMARY KEY, -- KSUID
+    user_id text NOT NULL CONSTRAINT tos_agreements_user_id_fkey REFERENCES users(id) ON DELETE CASCADE,
+    version text NOT NULL,
+    agreed_at timestamptz NOT NULL
+);
+
+CREATE INDEX tos_agreements_user_id_agreed_at_idx ON tos_agreements (user_id, agreed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+DROP TABLE tos_agreements;
+
+ALTER TABLE users DROP COLUMN agreed_tos_version;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- Events waiting to be relayed to Kafka. Rows are written in the same transaction as the
+-- change they describe and deleted once published.
+CREATE TABLE outbox (
+    id bigserial PRIMARY KEY,
+    user_id text NOT NULL, -- No foreign key: deletion events outlive the user.
+    event_type text NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    last_error text
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+DROP TABLE outbox;
+-- +goose StatementEnd
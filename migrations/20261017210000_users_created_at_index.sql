@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- Supports keyset pagination in ListUsers.
+CREATE INDEX users_created_at_id_idx ON users (created_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+DROP INDEX users_created_at_id_idx;
+-- +goose StatementEnd
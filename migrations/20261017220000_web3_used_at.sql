@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+ALTER TABLE users ADD COLUMN web3_used_at timestamptz;
+
+-- Tracks how far each chain indexer has read.
+CREATE TABLE indexer_checkpoints (
+    name text PRIMARY KEY,
+    -- next_block is the first block that has not been fully processed.
+    next_block bigint NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+DROP TABLE indexer_checkpoints;
+
+ALTER TABLE users DROP COLUMN web3_used_at;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- Bring existing addresses in line with EmailNormalizer: trimmed, with a lowercase domain.
+-- Plus-address folding is configurable, so we leave it to new writes.
+UPDATE users
+SET email_address = substring(a from '^(.*)@') || '@' || lower(substring(a from '@([^@]*)$'))
+FROM (SELECT id, btrim(email_address, E' \t\r\n') AS a FROM users WHERE email_address LIKE '%@%') t
+WHERE users.id = t.id
+    AND users.email_address <> substring(a from '^(.*)@') || '@' || lower(substring(a from '@([^@]*)$'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- The original forms are gone.
+SET search_path TO users_api, public;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- Run "users-api email-conflicts" first: this fails if any confirmed addresses collide.
+CREATE UNIQUE INDEX users_confirmed_email_address_lower_key ON users (lower(email_address)) WHERE email_confirmed;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+DROP INDEX users_confirmed_email_address_lower_key;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- merged_into_id is set on a duplicate account once merge-duplicates has folded it into
+-- another. The row stays so that its login still resolves.
+ALTER TABLE users ADD COLUMN merged_into_id text REFERENCES users(id) ON DELETE SET NULL;
+
+-- One row per merged account. There are no foreign keys, so that the record outlives the
+-- users involved.
+CREATE TABLE user_merges (
+    id text PRIMARY KEY,
+    survivor_id text NOT NULL,
+    merged_id text NOT NULL,
+    ethereum_address bytea NOT NULL,
+    actor text NOT NULL,
+    -- plan is the merge plan that was carried out.
+    plan jsonb NOT NULL,
+    -- merged_user is the merged account as it was before the merge.
+    merged_user jsonb NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX user_merges_survivor_id_idx ON user_merges (survivor_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+DROP TABLE user_merges;
+
+ALTER TABLE users DROP COLUMN merged_into_id;
+-- +goose StatementEnd
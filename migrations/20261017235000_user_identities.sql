@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- user_identities maps a login, the provider_id and sub claims of a token, to the user it
+-- acts as. Several logins can share one user.
+CREATE TABLE user_identities (
+    provider_id text NOT NULL,
+    subject text NOT NULL,
+    user_id text NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider_id, subject)
+);
+
+CREATE INDEX user_identities_subject_idx ON user_identities (subject);
+CREATE INDEX user_identities_user_id_idx ON user_identities (user_id);
+
+-- Every existing row is a login. Merged accounts act as the end of their merge chain;
+-- other accounts act as themselves, even if they share a confirmed address. Those are
+-- consolidated by merge-duplicates, which records each merge.
+WITH RECURSIVE chain AS (
+    SELECT id, id AS user_id, merged_into_id FROM users
+    UNION ALL
+    SELECT chain.id, users.id, users.merged_into_id
+    FROM chain JOIN users ON users.id = chain.merged_into_id
+)
+INSERT INTO user_identities (provider_id, subject, user_id)
+SELECT users.auth_provider_id, chain.id, chain.user_id
+FROM chain JOIN users ON users.id = chain.id
+WHERE chain.merged_into_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+DROP TABLE user_identities;
+-- +goose StatementEnd
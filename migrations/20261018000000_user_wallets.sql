@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- user_wallets holds every wallet a user has added. The primary wallet is mirrored in the
+-- ethereum_address, ethereum_confirmed and in_app_wallet columns of users.
+CREATE TABLE user_wallets (
+    user_id text NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    address bytea NOT NULL CHECK (length(address) = 20),
+    kind text NOT NULL CHECK (kind IN ('external', 'in_app')),
+    label text,
+    -- confirmed_at is when the user proved ownership by signing a challenge.
+    confirmed_at timestamptz,
+    is_primary boolean NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, address)
+);
+
+CREATE UNIQUE INDEX user_wallets_primary_key ON user_wallets (user_id) WHERE is_primary;
+CREATE INDEX user_wallets_address_idx ON user_wallets (address);
+
+-- We don't know when existing addresses were confirmed.
+INSERT INTO user_wallets (user_id, address, kind, confirmed_at, is_primary, created_at)
+SELECT
+    id,
+    ethereum_address,
+    CASE WHEN in_app_wallet THEN 'in_app' ELSE 'external' END,
+    CASE WHEN ethereum_confirmed THEN now() END,
+    true,
+    created_at
+FROM users
+WHERE length(ethereum_address) = 20;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+DROP TABLE user_wallets;
+-- +goose StatementEnd
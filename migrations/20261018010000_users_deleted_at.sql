@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- deleted_at is set when a user deletes their account. Until the grace period is over,
+-- the account can be restored; after that, it's purged.
+ALTER TABLE users ADD COLUMN deleted_at timestamptz;
+
+CREATE INDEX users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+ALTER TABLE users DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- One row per data export, kept as an audit trail. Asynchronous exports are also jobs:
+-- the worker fills in data, which is cleared once the download expires. There's no
+-- foreign key, so that the record outlives the user.
+CREATE TABLE user_exports (
+    id text PRIMARY KEY,
+    user_id text NOT NULL,
+    format text NOT NULL CHECK (format IN ('json', 'zip')),
+    async boolean NOT NULL,
+    status text NOT NULL CHECK (status IN ('pending', 'done', 'failed', 'expired')),
+    data bytea,
+    error text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    completed_at timestamptz
+);
+
+CREATE INDEX user_exports_user_id_idx ON user_exports (user_id, created_at);
+CREATE INDEX user_exports_pending_idx ON user_exports (created_at) WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+DROP TABLE user_exports;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- The relay looks for each user's oldest event.
+CREATE INDEX outbox_user_id_idx ON outbox (user_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+DROP INDEX outbox_user_id_idx;
+-- +goose StatementEnd
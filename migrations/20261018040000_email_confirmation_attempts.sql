@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- Failed guesses at the current email confirmation code.
+ALTER TABLE users ADD COLUMN email_confirmation_attempts integer NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+ALTER TABLE users DROP COLUMN email_confirmation_attempts;
+-- +goose StatementEnd
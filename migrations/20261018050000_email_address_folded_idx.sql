@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+-- For lookups with EMAIL_FOLD_PLUS, which fold addresses stored before it was turned on.
+-- The expression must match EmailNormalizer.LookupSQL.
+CREATE INDEX users_confirmed_email_address_folded_idx ON users (regexp_replace(lower(email_address), '^([^+@]+)\+[^@]*@', '\1@')) WHERE email_confirmed;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO users_api, public;
+
+DROP INDEX users_confirmed_email_address_folded_idx;
+-- +goose StatementEnd
@@ -4,7 +4,21 @@
 package models
 
 var TableNames = struct {
-	Users string
+	IndexerCheckpoints string
+	Outbox             string
+	TosAgreements      string
+	UserExports        string
+	UserIdentities     string
+	UserMerges         string
+	UserWallets        string
+	Users              string
 }{
-	Users: "users",
+	IndexerCheckpoints: "indexer_checkpoints",
+	Outbox:             "outbox",
+	TosAgreements:      "tos_agreements",
+	UserExports:        "user_exports",
+	UserIdentities:     "user_identities",
+	UserMerges:         "user_merges",
+	UserWallets:        "user_wallets",
+	Users:              "users",
 }
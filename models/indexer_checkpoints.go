@@ -0,0 +1,1024 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// IndexerCheckpoint is an object representing the database table.
+type IndexerCheckpoint struct {
+	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	NextBlock int64     `boil:"next_block" json:"next_block" toml:"next_block" yaml:"next_block"`
+	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *indexerCheckpointR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L indexerCheckpointL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var IndexerCheckpointColumns = struct {
+	Name      string
+	NextBlock string
+	UpdatedAt string
+}{
+	Name:      "name",
+	NextBlock: "next_block",
+	UpdatedAt: "updated_at",
+}
+
+var IndexerCheckpointTableColumns = struct {
+	Name      string
+	NextBlock string
+	UpdatedAt string
+}{
+	Name:      "indexer_checkpoints.name",
+	NextBlock: "indexer_checkpoints.next_block",
+	UpdatedAt: "indexer_checkpoints.updated_at",
+}
+
+// Generated where
+
+type whereHelperstring struct{ field string }
+
+func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
+func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
+func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
+func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
+func (w whereHelperstring) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var IndexerCheckpointWhere = struct {
+	Name      whereHelperstring
+	NextBlock whereHelperint64
+	UpdatedAt whereHelpertime_Time
+}{
+	Name:      whereHelperstring{field: "\"users_api\".\"indexer_checkpoints\".\"name\""},
+	NextBlock: whereHelperint64{field: "\"users_api\".\"indexer_checkpoints\".\"next_block\""},
+	UpdatedAt: whereHelpertime_Time{field: "\"users_api\".\"indexer_checkpoints\".\"updated_at\""},
+}
+
+// IndexerCheckpointRels is where relationship names are stored.
+var IndexerCheckpointRels = struct {
+}{}
+
+// indexerCheckpointR is where relationships are stored.
+type indexerCheckpointR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*indexerCheckpointR) NewStruct() *indexerCheckpointR {
+	return &indexerCheckpointR{}
+}
+
+// indexerCheckpointL is where Load methods for each relationship are stored.
+type indexerCheckpointL struct{}
+
+var (
+	indexerCheckpointAllColumns            = []string{"name", "next_block", "updated_at"}
+	indexerCheckpointColumnsWithoutDefault = []string{"name", "next_block"}
+	indexerCheckpointColumnsWithDefault    = []string{"updated_at"}
+	indexerCheckpointPrimaryKeyColumns     = []string{"name"}
+	indexerCheckpointGeneratedColumns      = []string{}
+)
+
+type (
+	// IndexerCheckpointSlice is an alias for a slice of pointers to IndexerCheckpoint.
+	// This should almost always be used instead of []IndexerCheckpoint.
+	IndexerCheckpointSlice []*IndexerCheckpoint
+	// IndexerCheckpointHook is the signature for custom IndexerCheckpoint hook methods
+	IndexerCheckpointHook func(context.Context, boil.ContextExecutor, *IndexerCheckpoint) error
+
+	indexerCheckpointQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	indexerCheckpointType                 = reflect.TypeOf(&IndexerCheckpoint{})
+	indexerCheckpointMapping              = queries.MakeStructMapping(indexerCheckpointType)
+	indexerCheckpointPrimaryKeyMapping, _ = queries.BindMapping(indexerCheckpointType, indexerCheckpointMapping, indexerCheckpointPrimaryKeyColumns)
+	indexerCheckpointInsertCacheMut       sync.RWMutex
+	indexerCheckpointInsertCache          = make(map[string]insertCache)
+	indexerCheckpointUpdateCacheMut       sync.RWMutex
+	indexerCheckpointUpdateCache          = make(map[string]updateCache)
+	indexerCheckpointUpsertCacheMut       sync.RWMutex
+	indexerCheckpointUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var indexerCheckpointAfterSelectMu sync.Mutex
+var indexerCheckpointAfterSelectHooks []IndexerCheckpointHook
+
+var indexerCheckpointBeforeInsertMu sync.Mutex
+var indexerCheckpointBeforeInsertHooks []IndexerCheckpointHook
+var indexerCheckpointAfterInsertMu sync.Mutex
+var indexerCheckpointAfterInsertHooks []IndexerCheckpointHook
+
+var indexerCheckpointBeforeUpdateMu sync.Mutex
+var indexerCheckpointBeforeUpdateHooks []IndexerCheckpointHook
+var indexerCheckpointAfterUpdateMu sync.Mutex
+var indexerCheckpointAfterUpdateHooks []IndexerCheckpointHook
+
+var indexerCheckpointBeforeDeleteMu sync.Mutex
+var indexerCheckpointBeforeDeleteHooks []IndexerCheckpointHook
+var indexerCheckpointAfterDeleteMu sync.Mutex
+var indexerCheckpointAfterDeleteHooks []IndexerCheckpointHook
+
+var indexerCheckpointBeforeUpsertMu sync.Mutex
+var indexerCheckpointBeforeUpsertHooks []IndexerCheckpointHook
+var indexerCheckpointAfterUpsertMu sync.Mutex
+var indexerCheckpointAfterUpsertHooks []IndexerCheckpointHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *IndexerCheckpoint) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range indexerCheckpointAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *IndexerCheckpoint) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range indexerCheckpointBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *IndexerCheckpoint) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range indexerCheckpointAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *IndexerCheckpoint) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range indexerCheckpointBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *IndexerCheckpoint) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range indexerCheckpointAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *IndexerCheckpoint) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range indexerCheckpointBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *IndexerCheckpoint) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range indexerCheckpointAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *IndexerCheckpoint) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range indexerCheckpointBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *IndexerCheckpoint) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range indexerCheckpointAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddIndexerCheckpointHook registers your hook function for all future operations.
+func AddIndexerCheckpointHook(hookPoint boil.HookPoint, indexerCheckpointHook IndexerCheckpointHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		indexerCheckpointAfterSelectMu.Lock()
+		indexerCheckpointAfterSelectHooks = append(indexerCheckpointAfterSelectHooks, indexerCheckpointHook)
+		indexerCheckpointAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		indexerCheckpointBeforeInsertMu.Lock()
+		indexerCheckpointBeforeInsertHooks = append(indexerCheckpointBeforeInsertHooks, indexerCheckpointHook)
+		indexerCheckpointBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		indexerCheckpointAfterInsertMu.Lock()
+		indexerCheckpointAfterInsertHooks = append(indexerCheckpointAfterInsertHooks, indexerCheckpointHook)
+		indexerCheckpointAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		indexerCheckpointBeforeUpdateMu.Lock()
+		indexerCheckpointBeforeUpdateHooks = append(indexerCheckpointBeforeUpdateHooks, indexerCheckpointHook)
+		indexerCheckpointBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		indexerCheckpointAfterUpdateMu.Lock()
+		indexerCheckpointAfterUpdateHooks = append(indexerCheckpointAfterUpdateHooks, indexerCheckpointHook)
+		indexerCheckpointAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		indexerCheckpointBeforeDeleteMu.Lock()
+		indexerCheckpointBeforeDeleteHooks = append(indexerCheckpointBeforeDeleteHooks, indexerCheckpointHook)
+		indexerCheckpointBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		indexerCheckpointAfterDeleteMu.Lock()
+		indexerCheckpointAfterDeleteHooks = append(indexerCheckpointAfterDeleteHooks, indexerCheckpointHook)
+		indexerCheckpointAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		indexerCheckpointBeforeUpsertMu.Lock()
+		indexerCheckpointBeforeUpsertHooks = append(indexerCheckpointBeforeUpsertHooks, indexerCheckpointHook)
+		indexerCheckpointBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		indexerCheckpointAfterUpsertMu.Lock()
+		indexerCheckpointAfterUpsertHooks = append(indexerCheckpointAfterUpsertHooks, indexerCheckpointHook)
+		indexerCheckpointAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single indexerCheckpoint record from the query.
+func (q indexerCheckpointQuery) One(ctx context.Context, exec boil.ContextExecutor) (*IndexerCheckpoint, error) {
+	o := &IndexerCheckpoint{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for indexer_checkpoints")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all IndexerCheckpoint records from the query.
+func (q indexerCheckpointQuery) All(ctx context.Context, exec boil.ContextExecutor) (IndexerCheckpointSlice, error) {
+	var o []*IndexerCheckpoint
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to IndexerCheckpoint slice")
+	}
+
+	if len(indexerCheckpointAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all IndexerCheckpoint records in the query.
+func (q indexerCheckpointQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count indexer_checkpoints rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q indexerCheckpointQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if indexer_checkpoints exists")
+	}
+
+	return count > 0, nil
+}
+
+// IndexerCheckpoints retrieves all the records using an executor.
+func IndexerCheckpoints(mods ...qm.QueryMod) indexerCheckpointQuery {
+	mods = append(mods, qm.From("\"users_api\".\"indexer_checkpoints\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"users_api\".\"indexer_checkpoints\".*"})
+	}
+
+	return indexerCheckpointQuery{q}
+}
+
+// FindIndexerCheckpoint retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindIndexerCheckpoint(ctx context.Context, exec boil.ContextExecutor, name string, selectCols ...string) (*IndexerCheckpoint, error) {
+	indexerCheckpointObj := &IndexerCheckpoint{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"users_api\".\"indexer_checkpoints\" where \"name\"=$1", sel,
+	)
+
+	q := queries.Raw(query, name)
+
+	err := q.Bind(ctx, exec, indexerCheckpointObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from indexer_checkpoints")
+	}
+
+	if err = indexerCheckpointObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return indexerCheckpointObj, err
+	}
+
+	return indexerCheckpointObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *IndexerCheckpoint) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no indexer_checkpoints provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(indexerCheckpointColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	indexerCheckpointInsertCacheMut.RLock()
+	cache, cached := indexerCheckpointInsertCache[key]
+	indexerCheckpointInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			indexerCheckpointAllColumns,
+			indexerCheckpointColumnsWithDefault,
+			indexerCheckpointColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(indexerCheckpointType, indexerCheckpointMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(indexerCheckpointType, indexerCheckpointMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"users_api\".\"indexer_checkpoints\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"users_api\".\"indexer_checkpoints\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into indexer_checkpoints")
+	}
+
+	if !cached {
+		indexerCheckpointInsertCacheMut.Lock()
+		indexerCheckpointInsertCache[key] = cache
+		indexerCheckpointInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the IndexerCheckpoint.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *IndexerCheckpoint) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	indexerCheckpointUpdateCacheMut.RLock()
+	cache, cached := indexerCheckpointUpdateCache[key]
+	indexerCheckpointUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			indexerCheckpointAllColumns,
+			indexerCheckpointPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update indexer_checkpoints, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"users_api\".\"indexer_checkpoints\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, indexerCheckpointPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(indexerCheckpointType, indexerCheckpointMapping, append(wl, indexerCheckpointPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update indexer_checkpoints row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for indexer_checkpoints")
+	}
+
+	if !cached {
+		indexerCheckpointUpdateCacheMut.Lock()
+		indexerCheckpointUpdateCache[key] = cache
+		indexerCheckpointUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q indexerCheckpointQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for indexer_checkpoints")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for indexer_checkpoints")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o IndexerCheckpointSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), indexerCheckpointPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"users_api\".\"indexer_checkpoints\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, indexerCheckpointPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in indexerCheckpoint slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all indexerCheckpoint")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *IndexerCheckpoint) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no indexer_checkpoints provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(indexerCheckpointColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	indexerCheckpointUpsertCacheMut.RLock()
+	cache, cached := indexerCheckpointUpsertCache[key]
+	indexerCheckpointUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			indexerCheckpointAllColumns,
+			indexerCheckpointColumnsWithDefault,
+			indexerCheckpointColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			indexerCheckpointAllColumns,
+			indexerCheckpointPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert indexer_checkpoints, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(indexerCheckpointAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(indexerCheckpointPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert indexer_checkpoints, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(indexerCheckpointPrimaryKeyColumns))
+			copy(conflict, indexerCheckpointPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"users_api\".\"indexer_checkpoints\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(indexerCheckpointType, indexerCheckpointMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(indexerCheckpointType, indexerCheckpointMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert indexer_checkpoints")
+	}
+
+	if !cached {
+		indexerCheckpointUpsertCacheMut.Lock()
+		indexerCheckpointUpsertCache[key] = cache
+		indexerCheckpointUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single IndexerCheckpoint record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *IndexerCheckpoint) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no IndexerCheckpoint provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), indexerCheckpointPrimaryKeyMapping)
+	sql := "DELETE FROM \"users_api\".\"indexer_checkpoints\" WHERE \"name\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from indexer_checkpoints")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for indexer_checkpoints")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q indexerCheckpointQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no indexerCheckpointQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from indexer_checkpoints")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for indexer_checkpoints")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o IndexerCheckpointSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(indexerCheckpointBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), indexerCheckpointPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"users_api\".\"indexer_checkpoints\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, indexerCheckpointPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from indexerCheckpoint slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for indexer_checkpoints")
+	}
+
+	if len(indexerCheckpointAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *IndexerCheckpoint) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindIndexerCheckpoint(ctx, exec, o.Name)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *IndexerCheckpointSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := IndexerCheckpointSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), indexerCheckpointPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"users_api\".\"indexer_checkpoints\".* FROM \"users_api\".\"indexer_checkpoints\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, indexerCheckpointPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in IndexerCheckpointSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// IndexerCheckpointExists checks if the IndexerCheckpoint row exists.
+func IndexerCheckpointExists(ctx context.Context, exec boil.ContextExecutor, name string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"users_api\".\"indexer_checkpoints\" where \"name\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, name)
+	}
+	row := exec.QueryRowContext(ctx, sql, name)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if indexer_checkpoints exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the IndexerCheckpoint row exists.
+func (o *IndexerCheckpoint) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return IndexerCheckpointExists(ctx, exec, o.Name)
+}
@@ -0,0 +1,1131 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// TosAgreement is an object representing the database table.
+type TosAgreement struct {
+	ID       string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	UserID   string    `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	Version  string    `boil:"version" json:"version" toml:"version" yaml:"version"`
+	AgreedAt time.Time `boil:"agreed_at" json:"agreed_at" toml:"agreed_at" yaml:"agreed_at"`
+
+	R *tosAgreementR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L tosAgreementL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var TosAgreementColumns = struct {
+	ID       string
+	UserID   string
+	Version  string
+	AgreedAt string
+}{
+	ID:       "id",
+	UserID:   "user_id",
+	Version:  "version",
+	AgreedAt: "agreed_at",
+}
+
+var TosAgreementTableColumns = struct {
+	ID       string
+	UserID   string
+	Version  string
+	AgreedAt string
+}{
+	ID:       "tos_agreements.id",
+	UserID:   "tos_agreements.user_id",
+	Version:  "tos_agreements.version",
+	AgreedAt: "tos_agreements.agreed_at",
+}
+
+// Generated where
+
+var TosAgreementWhere = struct {
+	ID       whereHelperstring
+	UserID   whereHelperstring
+	Version  whereHelperstring
+	AgreedAt whereHelpertime_Time
+}{
+	ID:       whereHelperstring{field: "\"users_api\".\"tos_agreements\".\"id\""},
+	UserID:   whereHelperstring{field: "\"users_api\".\"tos_agreements\".\"user_id\""},
+	Version:  whereHelperstring{field: "\"users_api\".\"tos_agreements\".\"version\""},
+	AgreedAt: whereHelpertime_Time{field: "\"users_api\".\"tos_agreements\".\"agreed_at\""},
+}
+
+// TosAgreementRels is where relationship names are stored.
+var TosAgreementRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// tosAgreementR is where relationships are stored.
+type tosAgreementR struct {
+	User *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*tosAgreementR) NewStruct() *tosAgreementR {
+	return &tosAgreementR{}
+}
+
+func (r *tosAgreementR) GetUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.User
+}
+
+// tosAgreementL is where Load methods for each relationship are stored.
+type tosAgreementL struct{}
+
+var (
+	tosAgreementAllColumns            = []string{"id", "user_id", "version", "agreed_at"}
+	tosAgreementColumnsWithoutDefault = []string{"id", "user_id", "version", "agreed_at"}
+	tosAgreementColumnsWithDefault    = []string{}
+	tosAgreementPrimaryKeyColumns     = []string{"id"}
+	tosAgreementGeneratedColumns      = []string{}
+)
+
+type (
+	// TosAgreementSlice is an alias for a slice of pointers to TosAgreement.
+	// This should almost always be used instead of []TosAgreement.
+	TosAgreementSlice []*TosAgreement
+	// TosAgreementHook is the signature for custom TosAgreement hook methods
+	TosAgreementHook func(context.Context, boil.ContextExecutor, *TosAgreement) error
+
+	tosAgreementQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	tosAgreementType                 = reflect.TypeOf(&TosAgreement{})
+	tosAgreementMapping              = queries.MakeStructMapping(tosAgreementType)
+	tosAgreementPrimaryKeyMapping, _ = queries.BindMapping(tosAgreementType, tosAgreementMapping, tosAgreementPrimaryKeyColumns)
+	tosAgreementInsertCacheMut       sync.RWMutex
+	tosAgreementInsertCache          = make(map[string]insertCache)
+	tosAgreementUpdateCacheMut       sync.RWMutex
+	tosAgreementUpdateCache          = make(map[string]updateCache)
+	tosAgreementUpsertCacheMut       sync.RWMutex
+	tosAgreementUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var tosAgreementAfterSelectMu sync.Mutex
+var tosAgreementAfterSelectHooks []TosAgreementHook
+
+var tosAgreementBeforeInsertMu sync.Mutex
+var tosAgreementBeforeInsertHooks []TosAgreementHook
+var tosAgreementAfterInsertMu sync.Mutex
+var tosAgreementAfterInsertHooks []TosAgreementHook
+
+var tosAgreementBeforeUpdateMu sync.Mutex
+var tosAgreementBeforeUpdateHooks []TosAgreementHook
+var tosAgreementAfterUpdateMu sync.Mutex
+var tosAgreementAfterUpdateHooks []TosAgreementHook
+
+var tosAgreementBeforeDeleteMu sync.Mutex
+var tosAgreementBeforeDeleteHooks []TosAgreementHook
+var tosAgreementAfterDeleteMu sync.Mutex
+var tosAgreementAfterDeleteHooks []TosAgreementHook
+
+var tosAgreementBeforeUpsertMu sync.Mutex
+var tosAgreementBeforeUpsertHooks []TosAgreementHook
+var tosAgreementAfterUpsertMu sync.Mutex
+var tosAgreementAfterUpsertHooks []TosAgreementHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *TosAgreement) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tosAgreementAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *TosAgreement) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tosAgreementBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *TosAgreement) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tosAgreementAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *TosAgreement) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tosAgreementBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *TosAgreement) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tosAgreementAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *TosAgreement) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tosAgreementBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *TosAgreement) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tosAgreementAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *TosAgreement) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tosAgreementBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *TosAgreement) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tosAgreementAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddTosAgreementHook registers your hook function for all future operations.
+func AddTosAgreementHook(hookPoint boil.HookPoint, tosAgreementHook TosAgreementHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		tosAgreementAfterSelectMu.Lock()
+		tosAgreementAfterSelectHooks = append(tosAgreementAfterSelectHooks, tosAgreementHook)
+		tosAgreementAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		tosAgreementBeforeInsertMu.Lock()
+		tosAgreementBeforeInsertHooks = append(tosAgreementBeforeInsertHooks, tosAgreementHook)
+		tosAgreementBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		tosAgreementAfterInsertMu.Lock()
+		tosAgreementAfterInsertHooks = append(tosAgreementAfterInsertHooks, tosAgreementHook)
+		tosAgreementAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		tosAgreementBeforeUpdateMu.Lock()
+		tosAgreementBeforeUpdateHooks = append(tosAgreementBeforeUpdateHooks, tosAgreementHook)
+		tosAgreementBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		tosAgreementAfterUpdateMu.Lock()
+		tosAgreementAfterUpdateHooks = append(tosAgreementAfterUpdateHooks, tosAgreementHook)
+		tosAgreementAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		tosAgreementBeforeDeleteMu.Lock()
+		tosAgreementBeforeDeleteHooks = append(tosAgreementBeforeDeleteHooks, tosAgreementHook)
+		tosAgreementBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		tosAgreementAfterDeleteMu.Lock()
+		tosAgreementAfterDeleteHooks = append(tosAgreementAfterDeleteHooks, tosAgreementHook)
+		tosAgreementAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		tosAgreementBeforeUpsertMu.Lock()
+		tosAgreementBeforeUpsertHooks = append(tosAgreementBeforeUpsertHooks, tosAgreementHook)
+		tosAgreementBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		tosAgreementAfterUpsertMu.Lock()
+		tosAgreementAfterUpsertHooks = append(tosAgreementAfterUpsertHooks, tosAgreementHook)
+		tosAgreementAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single tosAgreement record from the query.
+func (q tosAgreementQuery) One(ctx context.Context, exec boil.ContextExecutor) (*TosAgreement, error) {
+	o := &TosAgreement{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for tos_agreements")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all TosAgreement records from the query.
+func (q tosAgreementQuery) All(ctx context.Context, exec boil.ContextExecutor) (TosAgreementSlice, error) {
+	var o []*TosAgreement
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to TosAgreement slice")
+	}
+
+	if len(tosAgreementAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all TosAgreement records in the query.
+func (q tosAgreementQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count tos_agreements rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q tosAgreementQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if tos_agreements exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *TosAgreement) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (tosAgreementL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTosAgreement interface{}, mods queries.Applicator) error {
+	var slice []*TosAgreement
+	var object *TosAgreement
+
+	if singular {
+		var ok bool
+		object, ok = maybeTosAgreement.(*TosAgreement)
+		if !ok {
+			object = new(TosAgreement)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTosAgreement)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTosAgreement))
+			}
+		}
+	} else {
+		s, ok := maybeTosAgreement.(*[]*TosAgreement)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTosAgreement)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTosAgreement))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &tosAgreementR{}
+		}
+		args[object.UserID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &tosAgreementR{}
+			}
+
+			args[obj.UserID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users_api.users`),
+		qm.WhereIn(`users_api.users.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(userAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.TosAgreements = append(foreign.R.TosAgreements, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.TosAgreements = append(foreign.R.TosAgreements, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUser of the tosAgreement to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.TosAgreements.
+func (o *TosAgreement) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"users_api\".\"tos_agreements\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, tosAgreementPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &tosAgreementR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			TosAgreements: TosAgreementSlice{o},
+		}
+	} else {
+		related.R.TosAgreements = append(related.R.TosAgreements, o)
+	}
+
+	return nil
+}
+
+// TosAgreements retrieves all the records using an executor.
+func TosAgreements(mods ...qm.QueryMod) tosAgreementQuery {
+	mods = append(mods, qm.From("\"users_api\".\"tos_agreements\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"users_api\".\"tos_agreements\".*"})
+	}
+
+	return tosAgreementQuery{q}
+}
+
+// FindTosAgreement retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindTosAgreement(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*TosAgreement, error) {
+	tosAgreementObj := &TosAgreement{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"users_api\".\"tos_agreements\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, tosAgreementObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from tos_agreements")
+	}
+
+	if err = tosAgreementObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return tosAgreementObj, err
+	}
+
+	return tosAgreementObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *TosAgreement) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no tos_agreements provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(tosAgreementColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	tosAgreementInsertCacheMut.RLock()
+	cache, cached := tosAgreementInsertCache[key]
+	tosAgreementInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			tosAgreementAllColumns,
+			tosAgreementColumnsWithDefault,
+			tosAgreementColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(tosAgreementType, tosAgreementMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(tosAgreementType, tosAgreementMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"users_api\".\"tos_agreements\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"users_api\".\"tos_agreements\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into tos_agreements")
+	}
+
+	if !cached {
+		tosAgreementInsertCacheMut.Lock()
+		tosAgreementInsertCache[key] = cache
+		tosAgreementInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the TosAgreement.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *TosAgreement) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	tosAgreementUpdateCacheMut.RLock()
+	cache, cached := tosAgreementUpdateCache[key]
+	tosAgreementUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			tosAgreementAllColumns,
+			tosAgreementPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update tos_agreements, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"users_api\".\"tos_agreements\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, tosAgreementPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(tosAgreementType, tosAgreementMapping, append(wl, tosAgreementPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update tos_agreements row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for tos_agreements")
+	}
+
+	if !cached {
+		tosAgreementUpdateCacheMut.Lock()
+		tosAgreementUpdateCache[key] = cache
+		tosAgreementUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q tosAgreementQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for tos_agreements")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for tos_agreements")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o TosAgreementSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tosAgreementPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"users_api\".\"tos_agreements\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, tosAgreementPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in tosAgreement slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all tosAgreement")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *TosAgreement) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no tos_agreements provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(tosAgreementColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	tosAgreementUpsertCacheMut.RLock()
+	cache, cached := tosAgreementUpsertCache[key]
+	tosAgreementUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			tosAgreementAllColumns,
+			tosAgreementColumnsWithDefault,
+			tosAgreementColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			tosAgreementAllColumns,
+			tosAgreementPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert tos_agreements, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(tosAgreementAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(tosAgreementPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert tos_agreements, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(tosAgreementPrimaryKeyColumns))
+			copy(conflict, tosAgreementPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"users_api\".\"tos_agreements\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(tosAgreementType, tosAgreementMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(tosAgreementType, tosAgreementMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert tos_agreements")
+	}
+
+	if !cached {
+		tosAgreementUpsertCacheMut.Lock()
+		tosAgreementUpsertCache[key] = cache
+		tosAgreementUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single TosAgreement record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *TosAgreement) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no TosAgreement provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), tosAgreementPrimaryKeyMapping)
+	sql := "DELETE FROM \"users_api\".\"tos_agreements\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from tos_agreements")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for tos_agreements")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q tosAgreementQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no tosAgreementQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from tos_agreements")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for tos_agreements")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o TosAgreementSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(tosAgreementBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tosAgreementPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"users_api\".\"tos_agreements\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, tosAgreementPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from tosAgreement slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for tos_agreements")
+	}
+
+	if len(tosAgreementAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *TosAgreement) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTosAgreement(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *TosAgreementSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := TosAgreementSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tosAgreementPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"users_api\".\"tos_agreements\".* FROM \"users_api\".\"tos_agreements\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, tosAgreementPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in TosAgreementSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// TosAgreementExists checks if the TosAgreement row exists.
+func TosAgreementExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"users_api\".\"tos_agreements\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if tos_agreements exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the TosAgreement row exists.
+func (o *TosAgreement) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return TosAgreementExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,994 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// UserMerge is an object representing the database table.
+type UserMerge struct {
+	ID              string     `boil:"id" json:"id" toml:"id" yaml:"id"`
+	SurvivorID      string     `boil:"survivor_id" json:"survivor_id" toml:"survivor_id" yaml:"survivor_id"`
+	MergedID        string     `boil:"merged_id" json:"merged_id" toml:"merged_id" yaml:"merged_id"`
+	EthereumAddress []byte     `boil:"ethereum_address" json:"ethereum_address" toml:"ethereum_address" yaml:"ethereum_address"`
+	Actor           string     `boil:"actor" json:"actor" toml:"actor" yaml:"actor"`
+	Plan            types.JSON `boil:"plan" json:"plan" toml:"plan" yaml:"plan"`
+	MergedUser      types.JSON `boil:"merged_user" json:"merged_user" toml:"merged_user" yaml:"merged_user"`
+	CreatedAt       time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *userMergeR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L userMergeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var UserMergeColumns = struct {
+	ID              string
+	SurvivorID      string
+	MergedID        string
+	EthereumAddress string
+	Actor           string
+	Plan            string
+	MergedUser      string
+	CreatedAt       string
+}{
+	ID:              "id",
+	SurvivorID:      "survivor_id",
+	MergedID:        "merged_id",
+	EthereumAddress: "ethereum_address",
+	Actor:           "actor",
+	Plan:            "plan",
+	MergedUser:      "merged_user",
+	CreatedAt:       "created_at",
+}
+
+var UserMergeTableColumns = struct {
+	ID              string
+	SurvivorID      string
+	MergedID        string
+	EthereumAddress string
+	Actor           string
+	Plan            string
+	MergedUser      string
+	CreatedAt       string
+}{
+	ID:              "user_merges.id",
+	SurvivorID:      "user_merges.survivor_id",
+	MergedID:        "user_merges.merged_id",
+	EthereumAddress: "user_merges.ethereum_address",
+	Actor:           "user_merges.actor",
+	Plan:            "user_merges.plan",
+	MergedUser:      "user_merges.merged_user",
+	CreatedAt:       "user_merges.created_at",
+}
+
+// Generated where
+
+type whereHelper__byte struct{ field string }
+
+func (w whereHelper__byte) EQ(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelper__byte) NEQ(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelper__byte) LT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+var UserMergeWhere = struct {
+	ID              whereHelperstring
+	SurvivorID      whereHelperstring
+	MergedID        whereHelperstring
+	EthereumAddress whereHelper__byte
+	Actor           whereHelperstring
+	Plan            whereHelpertypes_JSON
+	MergedUser      whereHelpertypes_JSON
+	CreatedAt       whereHelpertime_Time
+}{
+	ID:              whereHelperstring{field: "\"users_api\".\"user_merges\".\"id\""},
+	SurvivorID:      whereHelperstring{field: "\"users_api\".\"user_merges\".\"survivor_id\""},
+	MergedID:        whereHelperstring{field: "\"users_api\".\"user_merges\".\"merged_id\""},
+	EthereumAddress: whereHelper__byte{field: "\"users_api\".\"user_merges\".\"ethereum_address\""},
+	Actor:           whereHelperstring{field: "\"users_api\".\"user_merges\".\"actor\""},
+	Plan:            whereHelpertypes_JSON{field: "\"users_api\".\"user_merges\".\"plan\""},
+	MergedUser:      whereHelpertypes_JSON{field: "\"users_api\".\"user_merges\".\"merged_user\""},
+	CreatedAt:       whereHelpertime_Time{field: "\"users_api\".\"user_merges\".\"created_at\""},
+}
+
+// UserMergeRels is where relationship names are stored.
+var UserMergeRels = struct {
+}{}
+
+// userMergeR is where relationships are stored.
+type userMergeR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*userMergeR) NewStruct() *userMergeR {
+	return &userMergeR{}
+}
+
+// userMergeL is where Load methods for each relationship are stored.
+type userMergeL struct{}
+
+var (
+	userMergeAllColumns            = []string{"id", "survivor_id", "merged_id", "ethereum_address", "actor", "plan", "merged_user", "created_at"}
+	userMergeColumnsWithoutDefault = []string{"id", "survivor_id", "merged_id", "ethereum_address", "actor", "plan", "merged_user"}
+	userMergeColumnsWithDefault    = []string{"created_at"}
+	userMergePrimaryKeyColumns     = []string{"id"}
+	userMergeGeneratedColumns      = []string{}
+)
+
+type (
+	// UserMergeSlice is an alias for a slice of pointers to UserMerge.
+	// This should almost always be used instead of []UserMerge.
+	UserMergeSlice []*UserMerge
+	// UserMergeHook is the signature for custom UserMerge hook methods
+	UserMergeHook func(context.Context, boil.ContextExecutor, *UserMerge) error
+
+	userMergeQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	userMergeType                 = reflect.TypeOf(&UserMerge{})
+	userMergeMapping              = queries.MakeStructMapping(userMergeType)
+	userMergePrimaryKeyMapping, _ = queries.BindMapping(userMergeType, userMergeMapping, userMergePrimaryKeyColumns)
+	userMergeInsertCacheMut       sync.RWMutex
+	userMergeInsertCache          = make(map[string]insertCache)
+	userMergeUpdateCacheMut       sync.RWMutex
+	userMergeUpdateCache          = make(map[string]updateCache)
+	userMergeUpsertCacheMut       sync.RWMutex
+	userMergeUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var userMergeAfterSelectMu sync.Mutex
+var userMergeAfterSelectHooks []UserMergeHook
+
+var userMergeBeforeInsertMu sync.Mutex
+var userMergeBeforeInsertHooks []UserMergeHook
+var userMergeAfterInsertMu sync.Mutex
+var userMergeAfterInsertHooks []UserMergeHook
+
+var userMergeBeforeUpdateMu sync.Mutex
+var userMergeBeforeUpdateHooks []UserMergeHook
+var userMergeAfterUpdateMu sync.Mutex
+var userMergeAfterUpdateHooks []UserMergeHook
+
+var userMergeBeforeDeleteMu sync.Mutex
+var userMergeBeforeDeleteHooks []UserMergeHook
+var userMergeAfterDeleteMu sync.Mutex
+var userMergeAfterDeleteHooks []UserMergeHook
+
+var userMergeBeforeUpsertMu sync.Mutex
+var userMergeBeforeUpsertHooks []UserMergeHook
+var userMergeAfterUpsertMu sync.Mutex
+var userMergeAfterUpsertHooks []UserMergeHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *UserMerge) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userMergeAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *UserMerge) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userMergeBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *UserMerge) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userMergeAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *UserMerge) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userMergeBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *UserMerge) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userMergeAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *UserMerge) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userMergeBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *UserMerge) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userMergeAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *UserMerge) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userMergeBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *UserMerge) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userMergeAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddUserMergeHook registers your hook function for all future operations.
+func AddUserMergeHook(hookPoint boil.HookPoint, userMergeHook UserMergeHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		userMergeAfterSelectMu.Lock()
+		userMergeAfterSelectHooks = append(userMergeAfterSelectHooks, userMergeHook)
+		userMergeAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		userMergeBeforeInsertMu.Lock()
+		userMergeBeforeInsertHooks = append(userMergeBeforeInsertHooks, userMergeHook)
+		userMergeBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		userMergeAfterInsertMu.Lock()
+		userMergeAfterInsertHooks = append(userMergeAfterInsertHooks, userMergeHook)
+		userMergeAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		userMergeBeforeUpdateMu.Lock()
+		userMergeBeforeUpdateHooks = append(userMergeBeforeUpdateHooks, userMergeHook)
+		userMergeBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		userMergeAfterUpdateMu.Lock()
+		userMergeAfterUpdateHooks = append(userMergeAfterUpdateHooks, userMergeHook)
+		userMergeAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		userMergeBeforeDeleteMu.Lock()
+		userMergeBeforeDeleteHooks = append(userMergeBeforeDeleteHooks, userMergeHook)
+		userMergeBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		userMergeAfterDeleteMu.Lock()
+		userMergeAfterDeleteHooks = append(userMergeAfterDeleteHooks, userMergeHook)
+		userMergeAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		userMergeBeforeUpsertMu.Lock()
+		userMergeBeforeUpsertHooks = append(userMergeBeforeUpsertHooks, userMergeHook)
+		userMergeBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		userMergeAfterUpsertMu.Lock()
+		userMergeAfterUpsertHooks = append(userMergeAfterUpsertHooks, userMergeHook)
+		userMergeAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single userMerge record from the query.
+func (q userMergeQuery) One(ctx context.Context, exec boil.ContextExecutor) (*UserMerge, error) {
+	o := &UserMerge{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for user_merges")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all UserMerge records from the query.
+func (q userMergeQuery) All(ctx context.Context, exec boil.ContextExecutor) (UserMergeSlice, error) {
+	var o []*UserMerge
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to UserMerge slice")
+	}
+
+	if len(userMergeAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all UserMerge records in the query.
+func (q userMergeQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count user_merges rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q userMergeQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if user_merges exists")
+	}
+
+	return count > 0, nil
+}
+
+// UserMerges retrieves all the records using an executor.
+func UserMerges(mods ...qm.QueryMod) userMergeQuery {
+	mods = append(mods, qm.From("\"users_api\".\"user_merges\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"users_api\".\"user_merges\".*"})
+	}
+
+	return userMergeQuery{q}
+}
+
+// FindUserMerge retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindUserMerge(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*UserMerge, error) {
+	userMergeObj := &UserMerge{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"users_api\".\"user_merges\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, userMergeObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from user_merges")
+	}
+
+	if err = userMergeObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return userMergeObj, err
+	}
+
+	return userMergeObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *UserMerge) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no user_merges provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(userMergeColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	userMergeInsertCacheMut.RLock()
+	cache, cached := userMergeInsertCache[key]
+	userMergeInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			userMergeAllColumns,
+			userMergeColumnsWithDefault,
+			userMergeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(userMergeType, userMergeMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(userMergeType, userMergeMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"users_api\".\"user_merges\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"users_api\".\"user_merges\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into user_merges")
+	}
+
+	if !cached {
+		userMergeInsertCacheMut.Lock()
+		userMergeInsertCache[key] = cache
+		userMergeInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the UserMerge.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *UserMerge) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	userMergeUpdateCacheMut.RLock()
+	cache, cached := userMergeUpdateCache[key]
+	userMergeUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			userMergeAllColumns,
+			userMergePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update user_merges, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"users_api\".\"user_merges\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, userMergePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(userMergeType, userMergeMapping, append(wl, userMergePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update user_merges row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for user_merges")
+	}
+
+	if !cached {
+		userMergeUpdateCacheMut.Lock()
+		userMergeUpdateCache[key] = cache
+		userMergeUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q userMergeQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for user_merges")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for user_merges")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o UserMergeSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userMergePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"users_api\".\"user_merges\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, userMergePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in userMerge slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all userMerge")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *UserMerge) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no user_merges provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(userMergeColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	userMergeUpsertCacheMut.RLock()
+	cache, cached := userMergeUpsertCache[key]
+	userMergeUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			userMergeAllColumns,
+			userMergeColumnsWithDefault,
+			userMergeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			userMergeAllColumns,
+			userMergePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert user_merges, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(userMergeAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(userMergePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert user_merges, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(userMergePrimaryKeyColumns))
+			copy(conflict, userMergePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"users_api\".\"user_merges\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(userMergeType, userMergeMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(userMergeType, userMergeMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert user_merges")
+	}
+
+	if !cached {
+		userMergeUpsertCacheMut.Lock()
+		userMergeUpsertCache[key] = cache
+		userMergeUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single UserMerge record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *UserMerge) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no UserMerge provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), userMergePrimaryKeyMapping)
+	sql := "DELETE FROM \"users_api\".\"user_merges\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from user_merges")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for user_merges")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q userMergeQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no userMergeQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from user_merges")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for user_merges")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o UserMergeSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(userMergeBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userMergePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"users_api\".\"user_merges\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userMergePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from userMerge slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for user_merges")
+	}
+
+	if len(userMergeAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *UserMerge) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindUserMerge(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *UserMergeSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := UserMergeSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userMergePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"users_api\".\"user_merges\".* FROM \"users_api\".\"user_merges\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userMergePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in UserMergeSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// UserMergeExists checks if the UserMerge row exists.
+func UserMergeExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"users_api\".\"user_merges\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if user_merges exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the UserMerge row exists.
+func (o *UserMerge) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return UserMergeExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1167 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// UserWallet is an object representing the database table.
+type UserWallet struct {
+	UserID      string      `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	Address     []byte      `boil:"address" json:"address" toml:"address" yaml:"address"`
+	Kind        string      `boil:"kind" json:"kind" toml:"kind" yaml:"kind"`
+	Label       null.String `boil:"label" json:"label,omitempty" toml:"label" yaml:"label,omitempty"`
+	ConfirmedAt null.Time   `boil:"confirmed_at" json:"confirmed_at,omitempty" toml:"confirmed_at" yaml:"confirmed_at,omitempty"`
+	IsPrimary   bool        `boil:"is_primary" json:"is_primary" toml:"is_primary" yaml:"is_primary"`
+	CreatedAt   time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *userWalletR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L userWalletL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var UserWalletColumns = struct {
+	UserID      string
+	Address     string
+	Kind        string
+	Label       string
+	ConfirmedAt string
+	IsPrimary   string
+	CreatedAt   string
+}{
+	UserID:      "user_id",
+	Address:     "address",
+	Kind:        "kind",
+	Label:       "label",
+	ConfirmedAt: "confirmed_at",
+	IsPrimary:   "is_primary",
+	CreatedAt:   "created_at",
+}
+
+var UserWalletTableColumns = struct {
+	UserID      string
+	Address     string
+	Kind        string
+	Label       string
+	ConfirmedAt string
+	IsPrimary   string
+	CreatedAt   string
+}{
+	UserID:      "user_wallets.user_id",
+	Address:     "user_wallets.address",
+	Kind:        "user_wallets.kind",
+	Label:       "user_wallets.label",
+	ConfirmedAt: "user_wallets.confirmed_at",
+	IsPrimary:   "user_wallets.is_primary",
+	CreatedAt:   "user_wallets.created_at",
+}
+
+// Generated where
+
+var UserWalletWhere = struct {
+	UserID      whereHelperstring
+	Address     whereHelper__byte
+	Kind        whereHelperstring
+	Label       whereHelpernull_String
+	ConfirmedAt whereHelpernull_Time
+	IsPrimary   whereHelperbool
+	CreatedAt   whereHelpertime_Time
+}{
+	UserID:      whereHelperstring{field: "\"users_api\".\"user_wallets\".\"user_id\""},
+	Address:     whereHelper__byte{field: "\"users_api\".\"user_wallets\".\"address\""},
+	Kind:        whereHelperstring{field: "\"users_api\".\"user_wallets\".\"kind\""},
+	Label:       whereHelpernull_String{field: "\"users_api\".\"user_wallets\".\"label\""},
+	ConfirmedAt: whereHelpernull_Time{field: "\"users_api\".\"user_wallets\".\"confirmed_at\""},
+	IsPrimary:   whereHelperbool{field: "\"users_api\".\"user_wallets\".\"is_primary\""},
+	CreatedAt:   whereHelpertime_Time{field: "\"users_api\".\"user_wallets\".\"created_at\""},
+}
+
+// UserWalletRels is where relationship names are stored.
+var UserWalletRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// userWalletR is where relationships are stored.
+type userWalletR struct {
+	User *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*userWalletR) NewStruct() *userWalletR {
+	return &userWalletR{}
+}
+
+func (r *userWalletR) GetUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.User
+}
+
+// userWalletL is where Load methods for each relationship are stored.
+type userWalletL struct{}
+
+var (
+	userWalletAllColumns            = []string{"user_id", "address", "kind", "label", "confirmed_at", "is_primary", "created_at"}
+	userWalletColumnsWithoutDefault = []string{"user_id", "address", "kind"}
+	userWalletColumnsWithDefault    = []string{"label", "confirmed_at", "is_primary", "created_at"}
+	userWalletPrimaryKeyColumns     = []string{"user_id", "address"}
+	userWalletGeneratedColumns      = []string{}
+)
+
+type (
+	// UserWalletSlice is an alias for a slice of pointers to UserWallet.
+	// This should almost always be used instead of []UserWallet.
+	UserWalletSlice []*UserWallet
+	// UserWalletHook is the signature for custom UserWallet hook methods
+	UserWalletHook func(context.Context, boil.ContextExecutor, *UserWallet) error
+
+	userWalletQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	userWalletType                 = reflect.TypeOf(&UserWallet{})
+	userWalletMapping              = queries.MakeStructMapping(userWalletType)
+	userWalletPrimaryKeyMapping, _ = queries.BindMapping(userWalletType, userWalletMapping, userWalletPrimaryKeyColumns)
+	userWalletInsertCacheMut       sync.RWMutex
+	userWalletInsertCache          = make(map[string]insertCache)
+	userWalletUpdateCacheMut       sync.RWMutex
+	userWalletUpdateCache          = make(map[string]updateCache)
+	userWalletUpsertCacheMut       sync.RWMutex
+	userWalletUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var userWalletAfterSelectMu sync.Mutex
+var userWalletAfterSelectHooks []UserWalletHook
+
+var userWalletBeforeInsertMu sync.Mutex
+var userWalletBeforeInsertHooks []UserWalletHook
+var userWalletAfterInsertMu sync.Mutex
+var userWalletAfterInsertHooks []UserWalletHook
+
+var userWalletBeforeUpdateMu sync.Mutex
+var userWalletBeforeUpdateHooks []UserWalletHook
+var userWalletAfterUpdateMu sync.Mutex
+var userWalletAfterUpdateHooks []UserWalletHook
+
+var userWalletBeforeDeleteMu sync.Mutex
+var userWalletBeforeDeleteHooks []UserWalletHook
+var userWalletAfterDeleteMu sync.Mutex
+var userWalletAfterDeleteHooks []UserWalletHook
+
+var userWalletBeforeUpsertMu sync.Mutex
+var userWalletBeforeUpsertHooks []UserWalletHook
+var userWalletAfterUpsertMu sync.Mutex
+var userWalletAfterUpsertHooks []UserWalletHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *UserWallet) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userWalletAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *UserWallet) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userWalletBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *UserWallet) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userWalletAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *UserWallet) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userWalletBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *UserWallet) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userWalletAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *UserWallet) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userWalletBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *UserWallet) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userWalletAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *UserWallet) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userWalletBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *UserWallet) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userWalletAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddUserWalletHook registers your hook function for all future operations.
+func AddUserWalletHook(hookPoint boil.HookPoint, userWalletHook UserWalletHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		userWalletAfterSelectMu.Lock()
+		userWalletAfterSelectHooks = append(userWalletAfterSelectHooks, userWalletHook)
+		userWalletAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		userWalletBeforeInsertMu.Lock()
+		userWalletBeforeInsertHooks = append(userWalletBeforeInsertHooks, userWalletHook)
+		userWalletBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		userWalletAfterInsertMu.Lock()
+		userWalletAfterInsertHooks = append(userWalletAfterInsertHooks, userWalletHook)
+		userWalletAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		userWalletBeforeUpdateMu.Lock()
+		userWalletBeforeUpdateHooks = append(userWalletBeforeUpdateHooks, userWalletHook)
+		userWalletBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		userWalletAfterUpdateMu.Lock()
+		userWalletAfterUpdateHooks = append(userWalletAfterUpdateHooks, userWalletHook)
+		userWalletAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		userWalletBeforeDeleteMu.Lock()
+		userWalletBeforeDeleteHooks = append(userWalletBeforeDeleteHooks, userWalletHook)
+		userWalletBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		userWalletAfterDeleteMu.Lock()
+		userWalletAfterDeleteHooks = append(userWalletAfterDeleteHooks, userWalletHook)
+		userWalletAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		userWalletBeforeUpsertMu.Lock()
+		userWalletBeforeUpsertHooks = append(userWalletBeforeUpsertHooks, userWalletHook)
+		userWalletBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		userWalletAfterUpsertMu.Lock()
+		userWalletAfterUpsertHooks = append(userWalletAfterUpsertHooks, userWalletHook)
+		userWalletAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single userWallet record from the query.
+func (q userWalletQuery) One(ctx context.Context, exec boil.ContextExecutor) (*UserWallet, error) {
+	o := &UserWallet{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for user_wallets")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all UserWallet records from the query.
+func (q userWalletQuery) All(ctx context.Context, exec boil.ContextExecutor) (UserWalletSlice, error) {
+	var o []*UserWallet
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to UserWallet slice")
+	}
+
+	if len(userWalletAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all UserWallet records in the query.
+func (q userWalletQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count user_wallets rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q userWalletQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if user_wallets exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *UserWallet) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (userWalletL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserWallet interface{}, mods queries.Applicator) error {
+	var slice []*UserWallet
+	var object *UserWallet
+
+	if singular {
+		var ok bool
+		object, ok = maybeUserWallet.(*UserWallet)
+		if !ok {
+			object = new(UserWallet)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUserWallet)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUserWallet))
+			}
+		}
+	} else {
+		s, ok := maybeUserWallet.(*[]*UserWallet)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUserWallet)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUserWallet))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userWalletR{}
+		}
+		args[object.UserID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userWalletR{}
+			}
+
+			args[obj.UserID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users_api.users`),
+		qm.WhereIn(`users_api.users.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(userAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.UserWallets = append(foreign.R.UserWallets, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.UserWallets = append(foreign.R.UserWallets, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUser of the userWallet to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.UserWallets.
+func (o *UserWallet) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"users_api\".\"user_wallets\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, userWalletPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.UserID, o.Address}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &userWalletR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			UserWallets: UserWalletSlice{o},
+		}
+	} else {
+		related.R.UserWallets = append(related.R.UserWallets, o)
+	}
+
+	return nil
+}
+
+// UserWallets retrieves all the records using an executor.
+func UserWallets(mods ...qm.QueryMod) userWalletQuery {
+	mods = append(mods, qm.From("\"users_api\".\"user_wallets\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"users_api\".\"user_wallets\".*"})
+	}
+
+	return userWalletQuery{q}
+}
+
+// FindUserWallet retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindUserWallet(ctx context.Context, exec boil.ContextExecutor, userID string, address []byte, selectCols ...string) (*UserWallet, error) {
+	userWalletObj := &UserWallet{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"users_api\".\"user_wallets\" where \"user_id\"=$1 AND \"address\"=$2", sel,
+	)
+
+	q := queries.Raw(query, userID, address)
+
+	err := q.Bind(ctx, exec, userWalletObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from user_wallets")
+	}
+
+	if err = userWalletObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return userWalletObj, err
+	}
+
+	return userWalletObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *UserWallet) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no user_wallets provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(userWalletColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	userWalletInsertCacheMut.RLock()
+	cache, cached := userWalletInsertCache[key]
+	userWalletInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			userWalletAllColumns,
+			userWalletColumnsWithDefault,
+			userWalletColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(userWalletType, userWalletMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(userWalletType, userWalletMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"users_api\".\"user_wallets\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"users_api\".\"user_wallets\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into user_wallets")
+	}
+
+	if !cached {
+		userWalletInsertCacheMut.Lock()
+		userWalletInsertCache[key] = cache
+		userWalletInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the UserWallet.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *UserWallet) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	userWalletUpdateCacheMut.RLock()
+	cache, cached := userWalletUpdateCache[key]
+	userWalletUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			userWalletAllColumns,
+			userWalletPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update user_wallets, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"users_api\".\"user_wallets\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, userWalletPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(userWalletType, userWalletMapping, append(wl, userWalletPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update user_wallets row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for user_wallets")
+	}
+
+	if !cached {
+		userWalletUpdateCacheMut.Lock()
+		userWalletUpdateCache[key] = cache
+		userWalletUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q userWalletQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for user_wallets")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for user_wallets")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o UserWalletSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userWalletPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"users_api\".\"user_wallets\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, userWalletPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in userWallet slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all userWallet")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *UserWallet) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no user_wallets provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(userWalletColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	userWalletUpsertCacheMut.RLock()
+	cache, cached := userWalletUpsertCache[key]
+	userWalletUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			userWalletAllColumns,
+			userWalletColumnsWithDefault,
+			userWalletColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			userWalletAllColumns,
+			userWalletPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert user_wallets, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(userWalletAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(userWalletPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert user_wallets, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(userWalletPrimaryKeyColumns))
+			copy(conflict, userWalletPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"users_api\".\"user_wallets\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(userWalletType, userWalletMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(userWalletType, userWalletMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert user_wallets")
+	}
+
+	if !cached {
+		userWalletUpsertCacheMut.Lock()
+		userWalletUpsertCache[key] = cache
+		userWalletUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single UserWallet record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *UserWallet) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no UserWallet provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), userWalletPrimaryKeyMapping)
+	sql := "DELETE FROM \"users_api\".\"user_wallets\" WHERE \"user_id\"=$1 AND \"address\"=$2"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from user_wallets")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for user_wallets")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q userWalletQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no userWalletQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from user_wallets")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for user_wallets")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o UserWalletSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(userWalletBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userWalletPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"users_api\".\"user_wallets\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userWalletPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from userWallet slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for user_wallets")
+	}
+
+	if len(userWalletAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *UserWallet) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindUserWallet(ctx, exec, o.UserID, o.Address)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *UserWalletSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := UserWalletSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userWalletPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"users_api\".\"user_wallets\".* FROM \"users_api\".\"user_wallets\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userWalletPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in UserWalletSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// UserWalletExists checks if the UserWallet row exists.
+func UserWalletExists(ctx context.Context, exec boil.ContextExecutor, userID string, address []byte) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"users_api\".\"user_wallets\" where \"user_id\"=$1 AND \"address\"=$2 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, userID, address)
+	}
+	row := exec.QueryRowContext(ctx, sql, userID, address)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if user_wallets exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the UserWallet row exists.
+func (o *UserWallet) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return UserWalletExists(ctx, exec, o.UserID, o.Address)
+}
@@ -24,319 +24,214 @@ import (
 
 // User is an object representing the database table.
 type User struct {
-	ID                      string      `boil:"id" json:"id" toml:"id" yaml:"id"`
-	EmailAddress            null.String `boil:"email_address" json:"email_address,omitempty" toml:"email_address" yaml:"email_address,omitempty"`
-	EmailConfirmed          bool        `boil:"email_confirmed" json:"email_confirmed" toml:"email_confirmed" yaml:"email_confirmed"`
-	EmailConfirmationSentAt null.Time   `boil:"email_confirmation_sent_at" json:"email_confirmation_sent_at,omitempty" toml:"email_confirmation_sent_at" yaml:"email_confirmation_sent_at,omitempty"`
-	EmailConfirmationKey    null.String `boil:"email_confirmation_key" json:"email_confirmation_key,omitempty" toml:"email_confirmation_key" yaml:"email_confirmation_key,omitempty"`
-	CreatedAt               time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	CountryCode             null.String `boil:"country_code" json:"country_code,omitempty" toml:"country_code" yaml:"country_code,omitempty"`
-	EthereumAddress         null.Bytes  `boil:"ethereum_address" json:"ethereum_address,omitempty" toml:"ethereum_address" yaml:"ethereum_address,omitempty"`
-	AgreedTosAt             null.Time   `boil:"agreed_tos_at" json:"agreed_tos_at,omitempty" toml:"agreed_tos_at" yaml:"agreed_tos_at,omitempty"`
-	AuthProviderID          string      `boil:"auth_provider_id" json:"auth_provider_id" toml:"auth_provider_id" yaml:"auth_provider_id"`
-	EthereumChallenge       null.String `boil:"ethereum_challenge" json:"ethereum_challenge,omitempty" toml:"ethereum_challenge" yaml:"ethereum_challenge,omitempty"`
-	EthereumChallengeSent   null.Time   `boil:"ethereum_challenge_sent" json:"ethereum_challenge_sent,omitempty" toml:"ethereum_challenge_sent" yaml:"ethereum_challenge_sent,omitempty"`
-	EthereumConfirmed       bool        `boil:"ethereum_confirmed" json:"ethereum_confirmed" toml:"ethereum_confirmed" yaml:"ethereum_confirmed"`
-	InAppWallet             bool        `boil:"in_app_wallet" json:"in_app_wallet" toml:"in_app_wallet" yaml:"in_app_wallet"`
-	ReferralCode            null.String `boil:"referral_code" json:"referral_code,omitempty" toml:"referral_code" yaml:"referral_code,omitempty"`
-	ReferredAt              null.Time   `boil:"referred_at" json:"referred_at,omitempty" toml:"referred_at" yaml:"referred_at,omitempty"`
-	ReferringUserID         null.String `boil:"referring_user_id" json:"referring_user_id,omitempty" toml:"referring_user_id" yaml:"referring_user_id,omitempty"`
-	MigratedAt              null.Time   `boil:"migrated_at" json:"migrated_at,omitempty" toml:"migrated_at" yaml:"migrated_at,omitempty"`
+	ID                        string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	EmailAddress              null.String `boil:"email_address" json:"email_address,omitempty" toml:"email_address" yaml:"email_address,omitempty"`
+	EmailConfirmed            bool        `boil:"email_confirmed" json:"email_confirmed" toml:"email_confirmed" yaml:"email_confirmed"`
+	EmailConfirmationSentAt   null.Time   `boil:"email_confirmation_sent_at" json:"email_confirmation_sent_at,omitempty" toml:"email_confirmation_sent_at" yaml:"email_confirmation_sent_at,omitempty"`
+	EmailConfirmationKey      null.String `boil:"email_confirmation_key" json:"email_confirmation_key,omitempty" toml:"email_confirmation_key" yaml:"email_confirmation_key,omitempty"`
+	CreatedAt                 time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	CountryCode               null.String `boil:"country_code" json:"country_code,omitempty" toml:"country_code" yaml:"country_code,omitempty"`
+	EthereumAddress           null.Bytes  `boil:"ethereum_address" json:"ethereum_address,omitempty" toml:"ethereum_address" yaml:"ethereum_address,omitempty"`
+	AgreedTosAt               null.Time   `boil:"agreed_tos_at" json:"agreed_tos_at,omitempty" toml:"agreed_tos_at" yaml:"agreed_tos_at,omitempty"`
+	AuthProviderID            string      `boil:"auth_provider_id" json:"auth_provider_id" toml:"auth_provider_id" yaml:"auth_provider_id"`
+	EthereumChallenge         null.String `boil:"ethereum_challenge" json:"ethereum_challenge,omitempty" toml:"ethereum_challenge" yaml:"ethereum_challenge,omitempty"`
+	EthereumChallengeSent     null.Time   `boil:"ethereum_challenge_sent" json:"ethereum_challenge_sent,omitempty" toml:"ethereum_challenge_sent" yaml:"ethereum_challenge_sent,omitempty"`
+	EthereumConfirmed         bool        `boil:"ethereum_confirmed" json:"ethereum_confirmed" toml:"ethereum_confirmed" yaml:"ethereum_confirmed"`
+	InAppWallet               bool        `boil:"in_app_wallet" json:"in_app_wallet" toml:"in_app_wallet" yaml:"in_app_wallet"`
+	ReferralCode              null.String `boil:"referral_code" json:"referral_code,omitempty" toml:"referral_code" yaml:"referral_code,omitempty"`
+	ReferredAt                null.Time   `boil:"referred_at" json:"referred_at,omitempty" toml:"referred_at" yaml:"referred_at,omitempty"`
+	ReferringUserID           null.String `boil:"referring_user_id" json:"referring_user_id,omitempty" toml:"referring_user_id" yaml:"referring_user_id,omitempty"`
+	MigratedAt                null.Time   `boil:"migrated_at" json:"migrated_at,omitempty" toml:"migrated_at" yaml:"migrated_at,omitempty"`
+	AgreedTosVersion          null.String `boil:"agreed_tos_version" json:"agreed_tos_version,omitempty" toml:"agreed_tos_version" yaml:"agreed_tos_version,omitempty"`
+	Web3UsedAt                null.Time   `boil:"web3_used_at" json:"web3_used_at,omitempty" toml:"web3_used_at" yaml:"web3_used_at,omitempty"`
+	MergedIntoID              null.String `boil:"merged_into_id" json:"merged_into_id,omitempty" toml:"merged_into_id" yaml:"merged_into_id,omitempty"`
+	DeletedAt                 null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	EmailConfirmationAttempts int         `boil:"email_confirmation_attempts" json:"email_confirmation_attempts" toml:"email_confirmation_attempts" yaml:"email_confirmation_attempts"`
 
 	R *userR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var UserColumns = struct {
-	ID                      string
-	EmailAddress            string
-	EmailConfirmed          string
-	EmailConfirmationSentAt string
-	EmailConfirmationKey    string
-	CreatedAt               string
-	CountryCode             string
-	EthereumAddress         string
-	AgreedTosAt             string
-	AuthProviderID          string
-	EthereumChallenge       string
-	EthereumChallengeSent   string
-	EthereumConfirmed       string
-	InAppWallet             string
-	ReferralCode            string
-	ReferredAt              string
-	ReferringUserID         string
-	MigratedAt              string
+	ID                        string
+	EmailAddress              string
+	EmailConfirmed            string
+	EmailConfirmationSentAt   string
+	EmailConfirmationKey      string
+	CreatedAt                 string
+	CountryCode               string
+	EthereumAddress           string
+	AgreedTosAt               string
+	AuthProviderID            string
+	EthereumChallenge         string
+	EthereumChallengeSent     string
+	EthereumConfirmed         string
+	InAppWallet               string
+	ReferralCode              string
+	ReferredAt                string
+	ReferringUserID           string
+	MigratedAt                string
+	AgreedTosVersion          string
+	Web3UsedAt                string
+	MergedIntoID              string
+	DeletedAt                 string
+	EmailConfirmationAttempts string
 }{
-	ID:                      "id",
-	EmailAddress:            "email_address",
-	EmailConfirmed:          "email_confirmed",
-	EmailConfirmationSentAt: "email_confirmation_sent_at",
-	EmailConfirmationKey:    "email_confirmation_key",
-	CreatedAt:               "created_at",
-	CountryCode:             "country_code",
-	EthereumAddress:         "ethereum_address",
-	AgreedTosAt:             "agreed_tos_at",
-	AuthProviderID:          "auth_provider_id",
-	EthereumChallenge:       "ethereum_challenge",
-	EthereumChallengeSent:   "ethereum_challenge_sent",
-	EthereumConfirmed:       "ethereum_confirmed",
-	InAppWallet:             "in_app_wallet",
-	ReferralCode:            "referral_code",
-	ReferredAt:              "referred_at",
-	ReferringUserID:         "referring_user_id",
-	MigratedAt:              "migrated_at",
+	ID:                        "id",
+	EmailAddress:              "email_address",
+	EmailConfirmed:            "email_confirmed",
+	EmailConfirmationSentAt:   "email_confirmation_sent_at",
+	EmailConfirmationKey:      "email_confirmation_key",
+	CreatedAt:                 "created_at",
+	CountryCode:               "country_code",
+	EthereumAddress:           "ethereum_address",
+	AgreedTosAt:               "agreed_tos_at",
+	AuthProviderID:            "auth_provider_id",
+	EthereumChallenge:         "ethereum_challenge",
+	EthereumChallengeSent:     "ethereum_challenge_sent",
+	EthereumConfirmed:         "ethereum_confirmed",
+	InAppWallet:               "in_app_wallet",
+	ReferralCode:              "referral_code",
+	ReferredAt:                "referred_at",
+	ReferringUserID:           "referring_user_id",
+	MigratedAt:                "migrated_at",
+	AgreedTosVersion:          "agreed_tos_version",
+	Web3UsedAt:                "web3_used_at",
+	MergedIntoID:              "merged_into_id",
+	DeletedAt:                 "deleted_at",
+	EmailConfirmationAttempts: "email_confirmation_attempts",
 }
 
 var UserTableColumns = struct {
-	ID                      string
-	EmailAddress            string
-	EmailConfirmed          string
-	EmailConfirmationSentAt string
-	EmailConfirmationKey    string
-	CreatedAt               string
-	CountryCode             string
-	EthereumAddress         string
-	AgreedTosAt             string
-	AuthProviderID          string
-	EthereumChallenge       string
-	EthereumChallengeSent   string
-	EthereumConfirmed       string
-	InAppWallet             string
-	ReferralCode            string
-	ReferredAt              string
-	ReferringUserID         string
-	MigratedAt              string
+	ID                        string
+	EmailAddress              string
+	EmailConfirmed            string
+	EmailConfirmationSentAt   string
+	EmailConfirmationKey      string
+	CreatedAt                 string
+	CountryCode               string
+	EthereumAddress           string
+	AgreedTosAt               string
+	AuthProviderID            string
+	EthereumChallenge         string
+	EthereumChallengeSent     string
+	EthereumConfirmed         string
+	InAppWallet               string
+	ReferralCode              string
+	ReferredAt                string
+	ReferringUserID           string
+	MigratedAt                string
+	AgreedTosVersion          string
+	Web3UsedAt                string
+	MergedIntoID              string
+	DeletedAt                 string
+	EmailConfirmationAttempts string
 }{
-	ID:                      "users.id",
-	EmailAddress:            "users.email_address",
-	EmailConfirmed:          "users.email_confirmed",
-	EmailConfirmationSentAt: "users.email_confirmation_sent_at",
-	EmailConfirmationKey:    "users.email_confirmation_key",
-	CreatedAt:               "users.created_at",
-	CountryCode:             "users.country_code",
-	EthereumAddress:         "users.ethereum_address",
-	AgreedTosAt:             "users.agreed_tos_at",
-	AuthProviderID:          "users.auth_provider_id",
-	EthereumChallenge:       "users.ethereum_challenge",
-	EthereumChallengeSent:   "users.ethereum_challenge_sent",
-	EthereumConfirmed:       "users.ethereum_confirmed",
-	InAppWallet:             "users.in_app_wallet",
-	ReferralCode:            "users.referral_code",
-	ReferredAt:              "users.referred_at",
-	ReferringUserID:         "users.referring_user_id",
-	MigratedAt:              "users.migrated_at",
+	ID:                        "users.id",
+	EmailAddress:              "users.email_address",
+	EmailConfirmed:            "users.email_confirmed",
+	EmailConfirmationSentAt:   "users.email_confirmation_sent_at",
+	EmailConfirmationKey:      "users.email_confirmation_key",
+	CreatedAt:                 "users.created_at",
+	CountryCode:               "users.country_code",
+	EthereumAddress:           "users.ethereum_address",
+	AgreedTosAt:               "users.agreed_tos_at",
+	AuthProviderID:            "users.auth_provider_id",
+	EthereumChallenge:         "users.ethereum_challenge",
+	EthereumChallengeSent:     "users.ethereum_challenge_sent",
+	EthereumConfirmed:         "users.ethereum_confirmed",
+	InAppWallet:               "users.in_app_wallet",
+	ReferralCode:              "users.referral_code",
+	ReferredAt:                "users.referred_at",
+	ReferringUserID:           "users.referring_user_id",
+	MigratedAt:                "users.migrated_at",
+	AgreedTosVersion:          "users.agreed_tos_version",
+	Web3UsedAt:                "users.web3_used_at",
+	MergedIntoID:              "users.merged_into_id",
+	DeletedAt:                 "users.deleted_at",
+	EmailConfirmationAttempts: "users.email_confirmation_attempts",
 }
 
 // Generated where
 
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
-func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
-func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
-func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" LIKE ?", x)
-}
-func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT LIKE ?", x)
-}
-func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" ILIKE ?", x)
-}
-func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT ILIKE ?", x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelperbool struct{ field string }
-
-func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
-type whereHelpernull_Time struct{ field string }
-
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelpernull_Bytes struct{ field string }
-
-func (w whereHelpernull_Bytes) EQ(x null.Bytes) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Bytes) NEQ(x null.Bytes) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Bytes) LT(x null.Bytes) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Bytes) LTE(x null.Bytes) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Bytes) GT(x null.Bytes) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Bytes) GTE(x null.Bytes) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-func (w whereHelpernull_Bytes) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Bytes) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
 var UserWhere = struct {
-	ID                      whereHelperstring
-	EmailAddress            whereHelpernull_String
-	EmailConfirmed          whereHelperbool
-	EmailConfirmationSentAt whereHelpernull_Time
-	EmailConfirmationKey    whereHelpernull_String
-	CreatedAt               whereHelpertime_Time
-	CountryCode             whereHelpernull_String
-	EthereumAddress         whereHelpernull_Bytes
-	AgreedTosAt             whereHelpernull_Time
-	AuthProviderID          whereHelperstring
-	EthereumChallenge       whereHelpernull_String
-	EthereumChallengeSent   whereHelpernull_Time
-	EthereumConfirmed       whereHelperbool
-	InAppWallet             whereHelperbool
-	ReferralCode            whereHelpernull_String
-	ReferredAt              whereHelpernull_Time
-	ReferringUserID         whereHelpernull_String
-	MigratedAt              whereHelpernull_Time
+	ID                        whereHelperstring
+	EmailAddress              whereHelpernull_String
+	EmailConfirmed            whereHelperbool
+	EmailConfirmationSentAt   whereHelpernull_Time
+	EmailConfirmationKey      whereHelpernull_String
+	CreatedAt                 whereHelpertime_Time
+	CountryCode               whereHelpernull_String
+	EthereumAddress           whereHelpernull_Bytes
+	AgreedTosAt               whereHelpernull_Time
+	AuthProviderID            whereHelperstring
+	EthereumChallenge         whereHelpernull_String
+	EthereumChallengeSent     whereHelpernull_Time
+	EthereumConfirmed         whereHelperbool
+	InAppWallet               whereHelperbool
+	ReferralCode              whereHelpernull_String
+	ReferredAt                whereHelpernull_Time
+	ReferringUserID           whereHelpernull_String
+	MigratedAt                whereHelpernull_Time
+	AgreedTosVersion          whereHelpernull_String
+	Web3UsedAt                whereHelpernull_Time
+	MergedIntoID              whereHelpernull_String
+	DeletedAt                 whereHelpernull_Time
+	EmailConfirmationAttempts whereHelperint
 }{
-	ID:                      whereHelperstring{field: "\"users_api\".\"users\".\"id\""},
-	EmailAddress:            whereHelpernull_String{field: "\"users_api\".\"users\".\"email_address\""},
-	EmailConfirmed:          whereHelperbool{field: "\"users_api\".\"users\".\"email_confirmed\""},
-	EmailConfirmationSentAt: whereHelpernull_Time{field: "\"users_api\".\"users\".\"email_confirmation_sent_at\""},
-	EmailConfirmationKey:    whereHelpernull_String{field: "\"users_api\".\"users\".\"email_confirmation_key\""},
-	CreatedAt:               whereHelpertime_Time{field: "\"users_api\".\"users\".\"created_at\""},
-	CountryCode:             whereHelpernull_String{field: "\"users_api\".\"users\".\"country_code\""},
-	EthereumAddress:         whereHelpernull_Bytes{field: "\"users_api\".\"users\".\"ethereum_address\""},
-	AgreedTosAt:             whereHelpernull_Time{field: "\"users_api\".\"users\".\"agreed_tos_at\""},
-	AuthProviderID:          whereHelperstring{field: "\"users_api\".\"users\".\"auth_provider_id\""},
-	EthereumChallenge:       whereHelpernull_String{field: "\"users_api\".\"users\".\"ethereum_challenge\""},
-	EthereumChallengeSent:   whereHelpernull_Time{field: "\"users_api\".\"users\".\"ethereum_challenge_sent\""},
-	EthereumConfirmed:       whereHelperbool{field: "\"users_api\".\"users\".\"ethereum_confirmed\""},
-	InAppWallet:             whereHelperbool{field: "\"users_api\".\"users\".\"in_app_wallet\""},
-	ReferralCode:            whereHelpernull_String{field: "\"users_api\".\"users\".\"referral_code\""},
-	ReferredAt:              whereHelpernull_Time{field: "\"users_api\".\"users\".\"referred_at\""},
-	ReferringUserID:         whereHelpernull_String{field: "\"users_api\".\"users\".\"referring_user_id\""},
-	MigratedAt:              whereHelpernull_Time{field: "\"users_api\".\"users\".\"migrated_at\""},
+	ID:                        whereHelperstring{field: "\"users_api\".\"users\".\"id\""},
+	EmailAddress:              whereHelpernull_String{field: "\"users_api\".\"users\".\"email_address\""},
+	EmailConfirmed:            whereHelperbool{field: "\"users_api\".\"users\".\"email_confirmed\""},
+	EmailConfirmationSentAt:   whereHelpernull_Time{field: "\"users_api\".\"users\".\"email_confirmation_sent_at\""},
+	EmailConfirmationKey:      whereHelpernull_String{field: "\"users_api\".\"users\".\"email_confirmation_key\""},
+	CreatedAt:                 whereHelpertime_Time{field: "\"users_api\".\"users\".\"created_at\""},
+	CountryCode:               whereHelpernull_String{field: "\"users_api\".\"users\".\"country_code\""},
+	EthereumAddress:           whereHelpernull_Bytes{field: "\"users_api\".\"users\".\"ethereum_address\""},
+	AgreedTosAt:               whereHelpernull_Time{field: "\"users_api\".\"users\".\"agreed_tos_at\""},
+	AuthProviderID:            whereHelperstring{field: "\"users_api\".\"users\".\"auth_provider_id\""},
+	EthereumChallenge:         whereHelpernull_String{field: "\"users_api\".\"users\".\"ethereum_challenge\""},
+	EthereumChallengeSent:     whereHelpernull_Time{field: "\"users_api\".\"users\".\"ethereum_challenge_sent\""},
+	EthereumConfirmed:         whereHelperbool{field: "\"users_api\".\"users\".\"ethereum_confirmed\""},
+	InAppWallet:               whereHelperbool{field: "\"users_api\".\"users\".\"in_app_wallet\""},
+	ReferralCode:              whereHelpernull_String{field: "\"users_api\".\"users\".\"referral_code\""},
+	ReferredAt:                whereHelpernull_Time{field: "\"users_api\".\"users\".\"referred_at\""},
+	ReferringUserID:           whereHelpernull_String{field: "\"users_api\".\"users\".\"referring_user_id\""},
+	MigratedAt:                whereHelpernull_Time{field: "\"users_api\".\"users\".\"migrated_at\""},
+	AgreedTosVersion:          whereHelpernull_String{field: "\"users_api\".\"users\".\"agreed_tos_version\""},
+	Web3UsedAt:                whereHelpernull_Time{field: "\"users_api\".\"users\".\"web3_used_at\""},
+	MergedIntoID:              whereHelpernull_String{field: "\"users_api\".\"users\".\"merged_into_id\""},
+	DeletedAt:                 whereHelpernull_Time{field: "\"users_api\".\"users\".\"deleted_at\""},
+	EmailConfirmationAttempts: whereHelperint{field: "\"users_api\".\"users\".\"email_confirmation_attempts\""},
 }
 
 // UserRels is where relationship names are stored.
 var UserRels = struct {
 	ReferringUser      string
+	MergedInto         string
+	TosAgreements      string
+	UserIdentities     string
+	UserWallets        string
 	ReferringUserUsers string
+	MergedIntoUsers    string
 }{
 	ReferringUser:      "ReferringUser",
+	MergedInto:         "MergedInto",
+	TosAgreements:      "TosAgreements",
+	UserIdentities:     "UserIdentities",
+	UserWallets:        "UserWallets",
 	ReferringUserUsers: "ReferringUserUsers",
+	MergedIntoUsers:    "MergedIntoUsers",
 }
 
 // userR is where relationships are stored.
 type userR struct {
-	ReferringUser      *User     `boil:"ReferringUser" json:"ReferringUser" toml:"ReferringUser" yaml:"ReferringUser"`
-	ReferringUserUsers UserSlice `boil:"ReferringUserUsers" json:"ReferringUserUsers" toml:"ReferringUserUsers" yaml:"ReferringUserUsers"`
+	ReferringUser      *User             `boil:"ReferringUser" json:"ReferringUser" toml:"ReferringUser" yaml:"ReferringUser"`
+	MergedInto         *User             `boil:"MergedInto" json:"MergedInto" toml:"MergedInto" yaml:"MergedInto"`
+	TosAgreements      TosAgreementSlice `boil:"TosAgreements" json:"TosAgreements" toml:"TosAgreements" yaml:"TosAgreements"`
+	UserIdentities     UserIdentitySlice `boil:"UserIdentities" json:"UserIdentities" toml:"UserIdentities" yaml:"UserIdentities"`
+	UserWallets        UserWalletSlice   `boil:"UserWallets" json:"UserWallets" toml:"UserWallets" yaml:"UserWallets"`
+	ReferringUserUsers UserSlice         `boil:"ReferringUserUsers" json:"ReferringUserUsers" toml:"ReferringUserUsers" yaml:"ReferringUserUsers"`
+	MergedIntoUsers    UserSlice         `boil:"MergedIntoUsers" json:"MergedIntoUsers" toml:"MergedIntoUsers" yaml:"MergedIntoUsers"`
 }
 
 // NewStruct creates a new relationship struct
@@ -351,6 +246,34 @@ func (r *userR) GetReferringUser() *User {
 	return r.ReferringUser
 }
 
+func (r *userR) GetMergedInto() *User {
+	if r == nil {
+		return nil
+	}
+	return r.MergedInto
+}
+
+func (r *userR) GetTosAgreements() TosAgreementSlice {
+	if r == nil {
+		return nil
+	}
+	return r.TosAgreements
+}
+
+func (r *userR) GetUserIdentities() UserIdentitySlice {
+	if r == nil {
+		return nil
+	}
+	return r.UserIdentities
+}
+
+func (r *userR) GetUserWallets() UserWalletSlice {
+	if r == nil {
+		return nil
+	}
+	return r.UserWallets
+}
+
 func (r *userR) GetReferringUserUsers() UserSlice {
 	if r == nil {
 		return nil
@@ -358,13 +281,20 @@ func (r *userR) GetReferringUserUsers() UserSlice {
 	return r.ReferringUserUsers
 }
 
+func (r *userR) GetMergedIntoUsers() UserSlice {
+	if r == nil {
+		return nil
+	}
+	return r.MergedIntoUsers
+}
+
 // userL is where Load methods for each relationship are stored.
 type userL struct{}
 
 var (
-	userAllColumns            = []string{"id", "email_address", "email_confirmed", "email_confirmation_sent_at", "email_confirmation_key", "created_at", "country_code", "ethereum_address", "agreed_tos_at", "auth_provider_id", "ethereum_challenge", "ethereum_challenge_sent", "ethereum_confirmed", "in_app_wallet", "referral_code", "referred_at", "referring_user_id", "migrated_at"}
+	userAllColumns            = []string{"id", "email_address", "email_confirmed", "email_confirmation_sent_at", "email_confirmation_key", "created_at", "country_code", "ethereum_address", "agreed_tos_at", "auth_provider_id", "ethereum_challenge", "ethereum_challenge_sent", "ethereum_confirmed", "in_app_wallet", "referral_code", "referred_at", "referring_user_id", "migrated_at", "agreed_tos_version", "web3_used_at", "merged_into_id", "deleted_at", "email_confirmation_attempts"}
 	userColumnsWithoutDefault = []string{"id", "email_confirmed", "created_at", "auth_provider_id", "ethereum_confirmed"}
-	userColumnsWithDefault    = []string{"email_address", "email_confirmation_sent_at", "email_confirmation_key", "country_code", "ethereum_address", "agreed_tos_at", "ethereum_challenge", "ethereum_challenge_sent", "in_app_wallet", "referral_code", "referred_at", "referring_user_id", "migrated_at"}
+	userColumnsWithDefault    = []string{"email_address", "email_confirmation_sent_at", "email_confirmation_key", "country_code", "ethereum_address", "agreed_tos_at", "ethereum_challenge", "ethereum_challenge_sent", "in_app_wallet", "referral_code", "referred_at", "referring_user_id", "migrated_at", "agreed_tos_version", "web3_used_at", "merged_into_id", "deleted_at", "email_confirmation_attempts"}
 	userPrimaryKeyColumns     = []string{"id"}
 	userGeneratedColumns      = []string{}
 )
@@ -685,6 +615,59 @@ func (o *User) ReferringUser(mods ...qm.QueryMod) userQuery {
 	return Users(queryMods...)
 }
 
+// MergedInto pointed to by the foreign key.
+func (o *User) MergedInto(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.MergedIntoID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// TosAgreements retrieves all the tos_agreement's TosAgreements with an executor.
+func (o *User) TosAgreements(mods ...qm.QueryMod) tosAgreementQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"users_api\".\"tos_agreements\".\"user_id\"=?", o.ID),
+	)
+
+	return TosAgreements(queryMods...)
+}
+
+// UserIdentities retrieves all the user_identity's UserIdentities with an executor.
+func (o *User) UserIdentities(mods ...qm.QueryMod) userIdentityQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"users_api\".\"user_identities\".\"user_id\"=?", o.ID),
+	)
+
+	return UserIdentities(queryMods...)
+}
+
+// UserWallets retrieves all the user_wallet's UserWallets with an executor.
+func (o *User) UserWallets(mods ...qm.QueryMod) userWalletQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"users_api\".\"user_wallets\".\"user_id\"=?", o.ID),
+	)
+
+	return UserWallets(queryMods...)
+}
+
 // ReferringUserUsers retrieves all the user's Users with an executor via referring_user_id column.
 func (o *User) ReferringUserUsers(mods ...qm.QueryMod) userQuery {
 	var queryMods []qm.QueryMod
@@ -699,6 +682,20 @@ func (o *User) ReferringUserUsers(mods ...qm.QueryMod) userQuery {
 	return Users(queryMods...)
 }
 
+// MergedIntoUsers retrieves all the user's Users with an executor via merged_into_id column.
+func (o *User) MergedIntoUsers(mods ...qm.QueryMod) userQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"users_api\".\"users\".\"merged_into_id\"=?", o.ID),
+	)
+
+	return Users(queryMods...)
+}
+
 // LoadReferringUser allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (userL) LoadReferringUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
@@ -823,9 +820,9 @@ func (userL) LoadReferringUser(ctx context.Context, e boil.ContextExecutor, sing
 	return nil
 }
 
-// LoadReferringUserUsers allows an eager lookup of values, cached into the
-// loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadReferringUserUsers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+// LoadMergedInto allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (userL) LoadMergedInto(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -856,13 +853,20 @@ func (userL) LoadReferringUserUsers(ctx context.Context, e boil.ContextExecutor,
 		if object.R == nil {
 			object.R = &userR{}
 		}
-		args[object.ID] = struct{}{}
+		if !queries.IsNil(object.MergedIntoID) {
+			args[object.MergedIntoID] = struct{}{}
+		}
+
 	} else {
 		for _, obj := range slice {
 			if obj.R == nil {
 				obj.R = &userR{}
 			}
-			args[obj.ID] = struct{}{}
+
+			if !queries.IsNil(obj.MergedIntoID) {
+				args[obj.MergedIntoID] = struct{}{}
+			}
+
 		}
 	}
 
@@ -879,7 +883,7 @@ func (userL) LoadReferringUserUsers(ctx context.Context, e boil.ContextExecutor,
 
 	query := NewQuery(
 		qm.From(`users_api.users`),
-		qm.WhereIn(`users_api.users.referring_user_id in ?`, argsSlice...),
+		qm.WhereIn(`users_api.users.id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -887,16 +891,16 @@ func (userL) LoadReferringUserUsers(ctx context.Context, e boil.ContextExecutor,
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load users")
+		return errors.Wrap(err, "failed to eager load User")
 	}
 
 	var resultSlice []*User
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice users")
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on users")
+		return errors.Wrap(err, "failed to close results of eager load for users")
 	}
 	if err = results.Err(); err != nil {
 		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
@@ -909,25 +913,29 @@ func (userL) LoadReferringUserUsers(ctx context.Context, e boil.ContextExecutor,
 			}
 		}
 	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
 	if singular {
-		object.R.ReferringUserUsers = resultSlice
-		for _, foreign := range resultSlice {
-			if foreign.R == nil {
-				foreign.R = &userR{}
-			}
-			foreign.R.ReferringUser = object
+		foreign := resultSlice[0]
+		object.R.MergedInto = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
 		}
+		foreign.R.MergedIntoUsers = append(foreign.R.MergedIntoUsers, object)
 		return nil
 	}
 
-	for _, foreign := range resultSlice {
-		for _, local := range slice {
-			if queries.Equal(local.ID, foreign.ReferringUserID) {
-				local.R.ReferringUserUsers = append(local.R.ReferringUserUsers, foreign)
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.MergedIntoID, foreign.ID) {
+				local.R.MergedInto = foreign
 				if foreign.R == nil {
 					foreign.R = &userR{}
 				}
-				foreign.R.ReferringUser = local
+				foreign.R.MergedIntoUsers = append(foreign.R.MergedIntoUsers, local)
 				break
 			}
 		}
@@ -936,69 +944,634 @@ func (userL) LoadReferringUserUsers(ctx context.Context, e boil.ContextExecutor,
 	return nil
 }
 
-// SetReferringUser of the user to the related item.
-// Sets o.R.ReferringUser to related.
-// Adds o to related.R.ReferringUserUsers.
-func (o *User) SetReferringUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
-	var err error
-	if insert {
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
-		}
-	}
-
-	updateQuery := fmt.Sprintf(
-		"UPDATE \"users_api\".\"users\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, []string{"referring_user_id"}),
-		strmangle.WhereClause("\"", "\"", 2, userPrimaryKeyColumns),
-	)
-	values := []interface{}{related.ID, o.ID}
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
-	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
-	}
+// LoadTosAgreements allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadTosAgreements(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
 
-	queries.Assign(&o.ReferringUserID, related.ID)
-	if o.R == nil {
-		o.R = &userR{
-			ReferringUser: related,
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
 		}
 	} else {
-		o.R.ReferringUser = related
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
 	}
 
-	if related.R == nil {
-		related.R = &userR{
-			ReferringUserUsers: UserSlice{o},
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
 		}
+		args[object.ID] = struct{}{}
 	} else {
-		related.R.ReferringUserUsers = append(related.R.ReferringUserUsers, o)
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
 	}
 
-	return nil
-}
-
-// RemoveReferringUser relationship.
-// Sets o.R.ReferringUser to nil.
-// Removes o from all passed in related items' relationships struct.
-func (o *User) RemoveReferringUser(ctx context.Context, exec boil.ContextExecutor, related *User) error {
-	var err error
-
-	queries.SetScanner(&o.ReferringUserID, nil)
-	if _, err = o.Update(ctx, exec, boil.Whitelist("referring_user_id")); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+	if len(args) == 0 {
+		return nil
 	}
 
-	if o.R != nil {
-		o.R.ReferringUser = nil
-	}
-	if related == nil || related.R == nil {
-		return nil
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users_api.tos_agreements`),
+		qm.WhereIn(`users_api.tos_agreements.user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load tos_agreements")
+	}
+
+	var resultSlice []*TosAgreement
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice tos_agreements")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on tos_agreements")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for tos_agreements")
+	}
+
+	if len(tosAgreementAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.TosAgreements = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &tosAgreementR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.TosAgreements = append(local.R.TosAgreements, foreign)
+				if foreign.R == nil {
+					foreign.R = &tosAgreementR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUserIdentities allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadUserIdentities(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users_api.user_identities`),
+		qm.WhereIn(`users_api.user_identities.user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_identities")
+	}
+
+	var resultSlice []*UserIdentity
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice user_identities")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_identities")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_identities")
+	}
+
+	if len(userIdentityAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.UserIdentities = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userIdentityR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.UserIdentities = append(local.R.UserIdentities, foreign)
+				if foreign.R == nil {
+					foreign.R = &userIdentityR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUserWallets allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadUserWallets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users_api.user_wallets`),
+		qm.WhereIn(`users_api.user_wallets.user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_wallets")
+	}
+
+	var resultSlice []*UserWallet
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice user_wallets")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_wallets")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_wallets")
+	}
+
+	if len(userWalletAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.UserWallets = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userWalletR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.UserWallets = append(local.R.UserWallets, foreign)
+				if foreign.R == nil {
+					foreign.R = &userWalletR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadReferringUserUsers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadReferringUserUsers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users_api.users`),
+		qm.WhereIn(`users_api.users.referring_user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load users")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice users")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(userAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ReferringUserUsers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userR{}
+			}
+			foreign.R.ReferringUser = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.ReferringUserID) {
+				local.R.ReferringUserUsers = append(local.R.ReferringUserUsers, foreign)
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.ReferringUser = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadMergedIntoUsers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadMergedIntoUsers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users_api.users`),
+		qm.WhereIn(`users_api.users.merged_into_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load users")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice users")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(userAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.MergedIntoUsers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userR{}
+			}
+			foreign.R.MergedInto = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.MergedIntoID) {
+				local.R.MergedIntoUsers = append(local.R.MergedIntoUsers, foreign)
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.MergedInto = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetReferringUser of the user to the related item.
+// Sets o.R.ReferringUser to related.
+// Adds o to related.R.ReferringUserUsers.
+func (o *User) SetReferringUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"users_api\".\"users\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"referring_user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, userPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.ReferringUserID, related.ID)
+	if o.R == nil {
+		o.R = &userR{
+			ReferringUser: related,
+		}
+	} else {
+		o.R.ReferringUser = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			ReferringUserUsers: UserSlice{o},
+		}
+	} else {
+		related.R.ReferringUserUsers = append(related.R.ReferringUserUsers, o)
+	}
+
+	return nil
+}
+
+// RemoveReferringUser relationship.
+// Sets o.R.ReferringUser to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *User) RemoveReferringUser(ctx context.Context, exec boil.ContextExecutor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.ReferringUserID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("referring_user_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.ReferringUser = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
 	}
 
 	for i, ri := range related.R.ReferringUserUsers {
@@ -1006,16 +1579,255 @@ func (o *User) RemoveReferringUser(ctx context.Context, exec boil.ContextExecuto
 			continue
 		}
 
-		ln := len(related.R.ReferringUserUsers)
+		ln := len(related.R.ReferringUserUsers)
+		if ln > 1 && i < ln-1 {
+			related.R.ReferringUserUsers[i] = related.R.ReferringUserUsers[ln-1]
+		}
+		related.R.ReferringUserUsers = related.R.ReferringUserUsers[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetMergedInto of the user to the related item.
+// Sets o.R.MergedInto to related.
+// Adds o to related.R.MergedIntoUsers.
+func (o *User) SetMergedInto(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"users_api\".\"users\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"merged_into_id"}),
+		strmangle.WhereClause("\"", "\"", 2, userPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.MergedIntoID, related.ID)
+	if o.R == nil {
+		o.R = &userR{
+			MergedInto: related,
+		}
+	} else {
+		o.R.MergedInto = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			MergedIntoUsers: UserSlice{o},
+		}
+	} else {
+		related.R.MergedIntoUsers = append(related.R.MergedIntoUsers, o)
+	}
+
+	return nil
+}
+
+// RemoveMergedInto relationship.
+// Sets o.R.MergedInto to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *User) RemoveMergedInto(ctx context.Context, exec boil.ContextExecutor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.MergedIntoID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("merged_into_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.MergedInto = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.MergedIntoUsers {
+		if queries.Equal(o.MergedIntoID, ri.MergedIntoID) {
+			continue
+		}
+
+		ln := len(related.R.MergedIntoUsers)
 		if ln > 1 && i < ln-1 {
-			related.R.ReferringUserUsers[i] = related.R.ReferringUserUsers[ln-1]
+			related.R.MergedIntoUsers[i] = related.R.MergedIntoUsers[ln-1]
 		}
-		related.R.ReferringUserUsers = related.R.ReferringUserUsers[:ln-1]
+		related.R.MergedIntoUsers = related.R.MergedIntoUsers[:ln-1]
 		break
 	}
 	return nil
 }
 
+// AddTosAgreements adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.TosAgreements.
+// Sets related.R.User appropriately.
+func (o *User) AddTosAgreements(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*TosAgreement) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"users_api\".\"tos_agreements\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, tosAgreementPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			TosAgreements: related,
+		}
+	} else {
+		o.R.TosAgreements = append(o.R.TosAgreements, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &tosAgreementR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddUserIdentities adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.UserIdentities.
+// Sets related.R.User appropriately.
+func (o *User) AddUserIdentities(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*UserIdentity) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"users_api\".\"user_identities\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userIdentityPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ProviderID, rel.Subject}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			UserIdentities: related,
+		}
+	} else {
+		o.R.UserIdentities = append(o.R.UserIdentities, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userIdentityR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddUserWallets adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.UserWallets.
+// Sets related.R.User appropriately.
+func (o *User) AddUserWallets(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*UserWallet) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"users_api\".\"user_wallets\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userWalletPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.UserID, rel.Address}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			UserWallets: related,
+		}
+	} else {
+		o.R.UserWallets = append(o.R.UserWallets, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userWalletR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
 // AddReferringUserUsers adds the given related objects to the existing relationships
 // of the user, optionally inserting them as new records.
 // Appends related to o.R.ReferringUserUsers.
@@ -1143,6 +1955,133 @@ func (o *User) RemoveReferringUserUsers(ctx context.Context, exec boil.ContextEx
 	return nil
 }
 
+// AddMergedIntoUsers adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.MergedIntoUsers.
+// Sets related.R.MergedInto appropriately.
+func (o *User) AddMergedIntoUsers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*User) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.MergedIntoID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"users_api\".\"users\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"merged_into_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.MergedIntoID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			MergedIntoUsers: related,
+		}
+	} else {
+		o.R.MergedIntoUsers = append(o.R.MergedIntoUsers, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userR{
+				MergedInto: o,
+			}
+		} else {
+			rel.R.MergedInto = o
+		}
+	}
+	return nil
+}
+
+// SetMergedIntoUsers removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.MergedInto's MergedIntoUsers accordingly.
+// Replaces o.R.MergedIntoUsers with related.
+// Sets related.R.MergedInto's MergedIntoUsers accordingly.
+func (o *User) SetMergedIntoUsers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*User) error {
+	query := "update \"users_api\".\"users\" set \"merged_into_id\" = null where \"merged_into_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.MergedIntoUsers {
+			queries.SetScanner(&rel.MergedIntoID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.MergedInto = nil
+		}
+		o.R.MergedIntoUsers = nil
+	}
+
+	return o.AddMergedIntoUsers(ctx, exec, insert, related...)
+}
+
+// RemoveMergedIntoUsers relationships from objects passed in.
+// Removes related items from R.MergedIntoUsers (uses pointer comparison, removal does not keep order)
+// Sets related.R.MergedInto.
+func (o *User) RemoveMergedIntoUsers(ctx context.Context, exec boil.ContextExecutor, related ...*User) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.MergedIntoID, nil)
+		if rel.R != nil {
+			rel.R.MergedInto = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("merged_into_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.MergedIntoUsers {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.MergedIntoUsers)
+			if ln > 1 && i < ln-1 {
+				o.R.MergedIntoUsers[i] = o.R.MergedIntoUsers[ln-1]
+			}
+			o.R.MergedIntoUsers = o.R.MergedIntoUsers[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // Users retrieves all the records using an executor.
 func Users(mods ...qm.QueryMod) userQuery {
 	mods = append(mods, qm.From("\"users_api\".\"users\""))
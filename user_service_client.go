@@ -16,6 +16,7 @@ import (
 	grpc "github.com/DIMO-Network/users-api/pkg/grpc"
 	gomock "go.uber.org/mock/gomock"
 	grpc0 "google.golang.org/grpc"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // MockUserServiceClient is a mock of UserServiceClient interface.
@@ -42,6 +43,26 @@ func (m *MockUserServiceClient) EXPECT() *MockUserServiceClientMockRecorder {
 	return m.recorder
 }
 
+// DeleteUser mocks base method.
+func (m *MockUserServiceClient) DeleteUser(ctx context.Context, in *grpc.DeleteUserRequest, opts ...grpc0.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteUser", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserServiceClientMockRecorder) DeleteUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserServiceClient)(nil).DeleteUser), varargs...)
+}
+
 // GetUser mocks base method.
 func (m *MockUserServiceClient) GetUser(ctx context.Context, in *grpc.GetUserRequest, opts ...grpc0.CallOption) (*grpc.User, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +103,26 @@ func (mr *MockUserServiceClientMockRecorder) GetUserByEthAddr(ctx, in any, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEthAddr", reflect.TypeOf((*MockUserServiceClient)(nil).GetUserByEthAddr), varargs...)
 }
 
+// GetUsers mocks base method.
+func (m *MockUserServiceClient) GetUsers(ctx context.Context, in *grpc.GetUsersRequest, opts ...grpc0.CallOption) (*grpc.GetUsersResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUsers", varargs...)
+	ret0, _ := ret[0].(*grpc.GetUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockUserServiceClientMockRecorder) GetUsers(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUserServiceClient)(nil).GetUsers), varargs...)
+}
+
 // GetUsersByEthereumAddress mocks base method.
 func (m *MockUserServiceClient) GetUsersByEthereumAddress(ctx context.Context, in *grpc.GetUsersByEthereumAddressRequest, opts ...grpc0.CallOption) (*grpc.GetUsersByEthereumAddressResponse, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +143,126 @@ func (mr *MockUserServiceClientMockRecorder) GetUsersByEthereumAddress(ctx, in a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByEthereumAddress", reflect.TypeOf((*MockUserServiceClient)(nil).GetUsersByEthereumAddress), varargs...)
 }
 
+// GetUsersByEthereumAddresses mocks base method.
+func (m *MockUserServiceClient) GetUsersByEthereumAddresses(ctx context.Context, in *grpc.GetUsersByEthereumAddressesRequest, opts ...grpc0.CallOption) (*grpc.GetUsersByEthereumAddressesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUsersByEthereumAddresses", varargs...)
+	ret0, _ := ret[0].(*grpc.GetUsersByEthereumAddressesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByEthereumAddresses indicates an expected call of GetUsersByEthereumAddresses.
+func (mr *MockUserServiceClientMockRecorder) GetUsersByEthereumAddresses(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByEthereumAddresses", reflect.TypeOf((*MockUserServiceClient)(nil).GetUsersByEthereumAddresses), varargs...)
+}
+
+// ListUserMergePlans mocks base method.
+func (m *MockUserServiceClient) ListUserMergePlans(ctx context.Context, in *grpc.ListUserMergePlansRequest, opts ...grpc0.CallOption) (*grpc.ListUserMergePlansResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUserMergePlans", varargs...)
+	ret0, _ := ret[0].(*grpc.ListUserMergePlansResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserMergePlans indicates an expected call of ListUserMergePlans.
+func (mr *MockUserServiceClientMockRecorder) ListUserMergePlans(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserMergePlans", reflect.TypeOf((*MockUserServiceClient)(nil).ListUserMergePlans), varargs...)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserServiceClient) ListUsers(ctx context.Context, in *grpc.ListUsersRequest, opts ...grpc0.CallOption) (grpc0.ServerStreamingClient[grpc.ListUsersResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUsers", varargs...)
+	ret0, _ := ret[0].(grpc0.ServerStreamingClient[grpc.ListUsersResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserServiceClientMockRecorder) ListUsers(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserServiceClient)(nil).ListUsers), varargs...)
+}
+
+// MergeUsers mocks base method.
+func (m *MockUserServiceClient) MergeUsers(ctx context.Context, in *grpc.MergeUsersRequest, opts ...grpc0.CallOption) (*grpc.UserMergePlan, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MergeUsers", varargs...)
+	ret0, _ := ret[0].(*grpc.UserMergePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeUsers indicates an expected call of MergeUsers.
+func (mr *MockUserServiceClientMockRecorder) MergeUsers(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeUsers", reflect.TypeOf((*MockUserServiceClient)(nil).MergeUsers), varargs...)
+}
+
+// ResolveIdentity mocks base method.
+func (m *MockUserServiceClient) ResolveIdentity(ctx context.Context, in *grpc.ResolveIdentityRequest, opts ...grpc0.CallOption) (*grpc.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResolveIdentity", varargs...)
+	ret0, _ := ret[0].(*grpc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveIdentity indicates an expected call of ResolveIdentity.
+func (mr *MockUserServiceClientMockRecorder) ResolveIdentity(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveIdentity", reflect.TypeOf((*MockUserServiceClient)(nil).ResolveIdentity), varargs...)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUserServiceClient) UpdateUser(ctx context.Context, in *grpc.UpdateUserRequest, opts ...grpc0.CallOption) (*grpc.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateUser", varargs...)
+	ret0, _ := ret[0].(*grpc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserServiceClientMockRecorder) UpdateUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserServiceClient)(nil).UpdateUser), varargs...)
+}
+
 // MockUserServiceServer is a mock of UserServiceServer interface.
 type MockUserServiceServer struct {
 	ctrl     *gomock.Controller
@@ -126,6 +287,21 @@ func (m *MockUserServiceServer) EXPECT() *MockUserServiceServerMockRecorder {
 	return m.recorder
 }
 
+// DeleteUser mocks base method.
+func (m *MockUserServiceServer) DeleteUser(arg0 context.Context, arg1 *grpc.DeleteUserRequest) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserServiceServerMockRecorder) DeleteUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserServiceServer)(nil).DeleteUser), arg0, arg1)
+}
+
 // GetUser mocks base method.
 func (m *MockUserServiceServer) GetUser(arg0 context.Context, arg1 *grpc.GetUserRequest) (*grpc.User, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +332,21 @@ func (mr *MockUserServiceServerMockRecorder) GetUserByEthAddr(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEthAddr", reflect.TypeOf((*MockUserServiceServer)(nil).GetUserByEthAddr), arg0, arg1)
 }
 
+// GetUsers mocks base method.
+func (m *MockUserServiceServer) GetUsers(arg0 context.Context, arg1 *grpc.GetUsersRequest) (*grpc.GetUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.GetUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockUserServiceServerMockRecorder) GetUsers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUserServiceServer)(nil).GetUsers), arg0, arg1)
+}
+
 // GetUsersByEthereumAddress mocks base method.
 func (m *MockUserServiceServer) GetUsersByEthereumAddress(arg0 context.Context, arg1 *grpc.GetUsersByEthereumAddressRequest) (*grpc.GetUsersByEthereumAddressResponse, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +362,95 @@ func (mr *MockUserServiceServerMockRecorder) GetUsersByEthereumAddress(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByEthereumAddress", reflect.TypeOf((*MockUserServiceServer)(nil).GetUsersByEthereumAddress), arg0, arg1)
 }
 
+// GetUsersByEthereumAddresses mocks base method.
+func (m *MockUserServiceServer) GetUsersByEthereumAddresses(arg0 context.Context, arg1 *grpc.GetUsersByEthereumAddressesRequest) (*grpc.GetUsersByEthereumAddressesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByEthereumAddresses", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.GetUsersByEthereumAddressesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByEthereumAddresses indicates an expected call of GetUsersByEthereumAddresses.
+func (mr *MockUserServiceServerMockRecorder) GetUsersByEthereumAddresses(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByEthereumAddresses", reflect.TypeOf((*MockUserServiceServer)(nil).GetUsersByEthereumAddresses), arg0, arg1)
+}
+
+// ListUserMergePlans mocks base method.
+func (m *MockUserServiceServer) ListUserMergePlans(arg0 context.Context, arg1 *grpc.ListUserMergePlansRequest) (*grpc.ListUserMergePlansResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserMergePlans", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.ListUserMergePlansResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserMergePlans indicates an expected call of ListUserMergePlans.
+func (mr *MockUserServiceServerMockRecorder) ListUserMergePlans(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserMergePlans", reflect.TypeOf((*MockUserServiceServer)(nil).ListUserMergePlans), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserServiceServer) ListUsers(arg0 *grpc.ListUsersRequest, arg1 grpc0.ServerStreamingServer[grpc.ListUsersResponse]) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserServiceServerMockRecorder) ListUsers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserServiceServer)(nil).ListUsers), arg0, arg1)
+}
+
+// MergeUsers mocks base method.
+func (m *MockUserServiceServer) MergeUsers(arg0 context.Context, arg1 *grpc.MergeUsersRequest) (*grpc.UserMergePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeUsers", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.UserMergePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeUsers indicates an expected call of MergeUsers.
+func (mr *MockUserServiceServerMockRecorder) MergeUsers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeUsers", reflect.TypeOf((*MockUserServiceServer)(nil).MergeUsers), arg0, arg1)
+}
+
+// ResolveIdentity mocks base method.
+func (m *MockUserServiceServer) ResolveIdentity(arg0 context.Context, arg1 *grpc.ResolveIdentityRequest) (*grpc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveIdentity", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveIdentity indicates an expected call of ResolveIdentity.
+func (mr *MockUserServiceServerMockRecorder) ResolveIdentity(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveIdentity", reflect.TypeOf((*MockUserServiceServer)(nil).ResolveIdentity), arg0, arg1)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUserServiceServer) UpdateUser(arg0 context.Context, arg1 *grpc.UpdateUserRequest) (*grpc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserServiceServerMockRecorder) UpdateUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserServiceServer)(nil).UpdateUser), arg0, arg1)
+}
+
 // mustEmbedUnimplementedUserServiceServer mocks base method.
 func (m *MockUserServiceServer) mustEmbedUnimplementedUserServiceServer() {
 	m.ctrl.T.Helper()